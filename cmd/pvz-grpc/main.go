@@ -1,16 +1,26 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 
+	"pvz-service/internal/adapter/auth/jwt"
+	"pvz-service/internal/adapter/auth/password"
 	"pvz-service/internal/adapter/db/postgres"
+	"pvz-service/internal/adapter/eventbus"
 	clockad "pvz-service/internal/adapter/time"
 	"pvz-service/internal/config"
 	"pvz-service/internal/transport/grpc/handler"
+	"pvz-service/internal/transport/grpc/interceptor"
 	"pvz-service/internal/transport/grpc/pb"
+	"pvz-service/internal/usecase/auth"
+	"pvz-service/internal/usecase/catalog"
+	"pvz-service/internal/usecase/city"
+	"pvz-service/internal/usecase/idempotency"
 	"pvz-service/internal/usecase/pvz"
+	"pvz-service/internal/usecase/reception"
 
 	"google.golang.org/grpc"
 )
@@ -23,17 +33,45 @@ func main() {
 		log.Fatal("Failed to connect DB: ", err)
 	}
 
+	tokenManager := jwt.NewTokenManagerJWT(cfg.JWT.Secret)
+	passwordHasher := password.NewHasher()
 	clock := clockad.RealClock{}
-	pvzService := pvz.NewService(db.PVZRepo(), db.ReceptionRepo(), db.ProductRepo(), clock)
+
+	authService := auth.NewService(db.UserRepo(), tokenManager, passwordHasher, clock)
+	cityService := city.NewService(db.CityRepo(), clock, city.DefaultCacheTTL)
+	pvzService := pvz.NewService(db, db.PVZReadModel(), cityService, clock)
+	catalogService := catalog.NewService(db.ProductTypeRepo(), clock, catalog.DefaultCacheTTL)
+	receptionService := reception.NewService(db, catalogService, clock, cfg.Reception.ReopenWindow)
+	idempotencyService := idempotency.NewService(db.IdempotencyRepo(), clock, cfg.Idempotency.TTL, cfg.Idempotency.Lease)
+
+	// Watch streams follow the outbox, so they see changes made by every
+	// process, the HTTP API and the stale sweeper included.
+	feed := eventbus.NewFeed(db.OutboxRepo(), eventbus.FeedConfig{
+		PollInterval: cfg.Watch.PollInterval,
+		BatchSize:    cfg.Watch.BatchSize,
+		HistorySize:  eventbus.DefaultHistorySize,
+	})
+	if err := feed.Start(context.Background()); err != nil {
+		log.Fatal("Failed to read the outbox: ", err)
+	}
+	go feed.Run(context.Background())
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.Server.GRPCPort))
 	if err != nil {
 		log.Fatal("Failed to listen: ", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	authInterceptor := interceptor.NewAuthInterceptor(tokenManager, interceptor.PublicMethods, interceptor.MethodRoles)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			authInterceptor.Unary(),
+			interceptor.NewIdempotencyInterceptor(idempotencyService, interceptor.IdempotentMethods).Unary(),
+		),
+		grpc.StreamInterceptor(authInterceptor.Stream()),
+	)
 
-	pb.RegisterPVZServiceServer(grpcServer, handler.NewPVZServer(pvzService))
+	pb.RegisterPVZServiceServer(grpcServer, handler.NewPVZServer(pvzService, receptionService, catalogService, feed))
+	pb.RegisterAuthServiceServer(grpcServer, handler.NewAuthServer(authService))
 
 	log.Printf("gRPC server started on port %d", cfg.Server.GRPCPort)
 	if err := grpcServer.Serve(lis); err != nil {
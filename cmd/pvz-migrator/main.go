@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net/url"
 
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose/v3"
@@ -12,8 +13,10 @@ import (
 
 func main() {
 	cfg := config.Load()
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
-		cfg.DB.User, cfg.DB.Password, cfg.DB.Host, cfg.DB.Port, cfg.DB.Name)
+	// 007_timestamptz reads the zone of the old TIMESTAMP values from pvz.legacy_timezone
+	options := url.QueryEscape("-c pvz.legacy_timezone=" + cfg.Migration.LegacyTimezone)
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable&options=%s",
+		cfg.DB.User, cfg.DB.Password, cfg.DB.Host, cfg.DB.Port, cfg.DB.Name, options)
 	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		log.Fatal("Failed to open DB: ", err)
@@ -3,10 +3,13 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"pvz-service/internal/adapter/db/repo"
 	"pvz-service/internal/usecase/ports"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -24,6 +27,14 @@ func NewDB(host string, port int, user, password, dbName string) (*PostgresDB, e
 	if err != nil {
 		return nil, err
 	}
+	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		conn.TypeMap().RegisterType(&pgtype.Type{
+			Name:  "timestamptz",
+			OID:   pgtype.TimestamptzOID,
+			Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+		})
+		return nil
+	}
 	pool, err := pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
 		return nil, err
@@ -51,6 +62,9 @@ func (db *PostgresDB) Begin(ctx context.Context) (ports.Tx, error) {
 		pvzRepo:       repo.NewPVZRepo(tx),
 		receptionRepo: repo.NewReceptionRepo(tx),
 		prodRepo:      repo.NewProductRepo(tx),
+		outboxRepo:    repo.NewOutboxRepo(tx),
+		webhookRepo:   repo.NewWebhookRepo(tx),
+		auditRepo:     repo.NewAuditRepo(tx),
 	}, nil
 }
 
@@ -66,3 +80,24 @@ func (db *PostgresDB) ReceptionRepo() ports.ReceptionRepository {
 func (db *PostgresDB) ProductRepo() ports.ProductRepository {
 	return repo.NewProductRepo(db.pool)
 }
+func (db *PostgresDB) OutboxRepo() ports.OutboxRepository {
+	return repo.NewOutboxRepo(db.pool)
+}
+func (db *PostgresDB) WebhookRepo() ports.WebhookRepository {
+	return repo.NewWebhookRepo(db.pool)
+}
+func (db *PostgresDB) ProductTypeRepo() ports.ProductTypeRepository {
+	return repo.NewProductTypeRepo(db.pool)
+}
+func (db *PostgresDB) CityRepo() ports.CityRepository {
+	return repo.NewCityRepo(db.pool)
+}
+func (db *PostgresDB) AuditRepo() ports.AuditRepository {
+	return repo.NewAuditRepo(db.pool)
+}
+func (db *PostgresDB) IdempotencyRepo() ports.IdempotencyRepository {
+	return repo.NewIdempotencyRepo(db.pool)
+}
+func (db *PostgresDB) PVZReadModel() ports.PVZReadModel {
+	return repo.NewPVZReadModel(db.pool)
+}
@@ -14,6 +14,9 @@ type PostgresTx struct {
 	pvzRepo       ports.PVZRepository
 	receptionRepo ports.ReceptionRepository
 	prodRepo      ports.ProductRepository
+	outboxRepo    ports.OutboxRepository
+	webhookRepo   ports.WebhookRepository
+	auditRepo     ports.AuditRepository
 }
 
 func (t *PostgresTx) UserRepo() ports.UserRepository {
@@ -28,6 +31,15 @@ func (t *PostgresTx) ReceptionRepo() ports.ReceptionRepository {
 func (t *PostgresTx) ProductRepo() ports.ProductRepository {
 	return t.prodRepo
 }
+func (t *PostgresTx) OutboxRepo() ports.OutboxRepository {
+	return t.outboxRepo
+}
+func (t *PostgresTx) WebhookRepo() ports.WebhookRepository {
+	return t.webhookRepo
+}
+func (t *PostgresTx) AuditRepo() ports.AuditRepository {
+	return t.auditRepo
+}
 func (t *PostgresTx) Commit() error {
 	return t.tx.Commit(context.Background())
 }
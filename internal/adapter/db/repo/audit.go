@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"pvz-service/internal/domain/audit"
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type PostgresAuditRepo struct {
+	conn interface {
+		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+		Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+		CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)
+	}
+}
+
+func NewAuditRepo(conn interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)
+}) *PostgresAuditRepo {
+	return &PostgresAuditRepo{conn: conn}
+}
+
+func (r *PostgresAuditRepo) Append(ctx context.Context, e audit.Entry) error {
+	details := e.Details
+	if details == nil {
+		details = map[string]string{}
+	}
+	_, err := r.conn.Exec(ctx,
+		`INSERT INTO audit_log(id, occurred_at, actor_id, actor_role, action, pvz_id, reception_id, product_id, details)
+		VALUES($1,$2,$3,$4,$5,NULLIF($6,'')::uuid,NULLIF($7,'')::uuid,NULLIF($8,'')::uuid,$9)`,
+		e.ID, e.OccurredAt, e.ActorID, e.ActorRole, string(e.Action), e.PVZID, e.ReceptionID, e.ProductID, details)
+	return err
+}
+
+// AppendMany writes the entries in one round trip.
+func (r *PostgresAuditRepo) AppendMany(ctx context.Context, entries []audit.Entry) error {
+	rows := make([][]any, 0, len(entries))
+	for _, e := range entries {
+		details := e.Details
+		if details == nil {
+			details = map[string]string{}
+		}
+		rows = append(rows, []any{
+			e.ID, e.OccurredAt, e.ActorID, e.ActorRole, string(e.Action),
+			nullIfZero(e.PVZID), nullIfZero(e.ReceptionID), nullIfZero(e.ProductID), details,
+		})
+	}
+	_, err := r.conn.CopyFrom(ctx, pgx.Identifier{"audit_log"},
+		[]string{"id", "occurred_at", "actor_id", "actor_role", "action", "pvz_id", "reception_id", "product_id", "details"},
+		pgx.CopyFromRows(rows))
+	return err
+}
+
+func (r *PostgresAuditRepo) List(ctx context.Context, filter ports.AuditFilter) ([]audit.Entry, error) {
+	query := `SELECT id, occurred_at, actor_id, actor_role, action,
+		COALESCE(pvz_id::text, ''), COALESCE(reception_id::text, ''), COALESCE(product_id::text, ''), details
+		FROM audit_log`
+	args := []any{}
+	whereParts := []string{}
+	if filter.PVZID != "" {
+		args = append(args, filter.PVZID)
+		whereParts = append(whereParts, fmt.Sprintf("pvz_id = $%d::uuid", len(args)))
+	}
+	if filter.ActorID != "" {
+		args = append(args, filter.ActorID)
+		whereParts = append(whereParts, fmt.Sprintf("actor_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		whereParts = append(whereParts, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		whereParts = append(whereParts, fmt.Sprintf("occurred_at <= $%d", len(args)))
+	}
+	if len(whereParts) > 0 {
+		query += " WHERE " + strings.Join(whereParts, " AND ")
+	}
+	query += " ORDER BY occurred_at DESC, id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var entries []audit.Entry
+	for rows.Next() {
+		var (
+			e      audit.Entry
+			action string
+		)
+		if err := rows.Scan(&e.ID, &e.OccurredAt, &e.ActorID, &e.ActorRole, &action,
+			&e.PVZID, &e.ReceptionID, &e.ProductID, &e.Details); err != nil {
+			return nil, err
+		}
+		e.Action = audit.Action(action)
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+
+	"pvz-service/internal/domain/pvz"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	citiesPKey   = "cities_pkey"
+	pvzsCityFKey = "pvzs_city_fkey"
+)
+
+type PostgresCityRepo struct {
+	conn interface {
+		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+		Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	}
+}
+
+func NewCityRepo(conn interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+}) *PostgresCityRepo {
+	return &PostgresCityRepo{conn: conn}
+}
+
+func (r *PostgresCityRepo) List(ctx context.Context) ([]pvz.City, error) {
+	rows, err := r.conn.Query(ctx,
+		"SELECT name, aliases, timezone, active, created_at FROM cities ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var cities []pvz.City
+	for rows.Next() {
+		var c pvz.City
+		if err := rows.Scan(&c.Name, &c.Aliases, &c.Timezone, &c.Active, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		cities = append(cities, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return cities, nil
+}
+
+func (r *PostgresCityRepo) Create(ctx context.Context, c *pvz.City) error {
+	_, err := r.conn.Exec(ctx,
+		"INSERT INTO cities(name, aliases, timezone, active, created_at) VALUES($1,$2,$3,$4,$5)",
+		c.Name, c.Aliases, c.Timezone, c.Active, c.CreatedAt)
+	if isUniqueViolation(err, citiesPKey) {
+		return pvz.ErrCityExists
+	}
+	return err
+}
+
+func (r *PostgresCityRepo) Update(ctx context.Context, c *pvz.City) error {
+	tag, err := r.conn.Exec(ctx,
+		"UPDATE cities SET aliases=$1, timezone=$2, active=$3 WHERE name=$4",
+		c.Aliases, c.Timezone, c.Active, c.Name)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pvz.ErrCityNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCityRepo) Delete(ctx context.Context, name string) error {
+	tag, err := r.conn.Exec(ctx, "DELETE FROM cities WHERE name=$1", name)
+	if isForeignKeyViolation(err, pvzsCityFKey) {
+		return pvz.ErrCityInUse
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pvz.ErrCityNotFound
+	}
+	return nil
+}
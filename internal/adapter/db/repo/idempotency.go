@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/idempotency"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type PostgresIdempotencyRepo struct {
+	conn interface {
+		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+		QueryRow(context.Context, string, ...interface{}) pgx.Row
+	}
+}
+
+func NewIdempotencyRepo(conn interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}) *PostgresIdempotencyRepo {
+	return &PostgresIdempotencyRepo{conn: conn}
+}
+
+func (r *PostgresIdempotencyRepo) Reserve(ctx context.Context, rec idempotency.Record) (*idempotency.Record, error) {
+	// an expired record is taken over as if the key were new, and so is an
+	// abandoned reservation of the same request once its lease has run out
+	var reserved bool
+	err := r.conn.QueryRow(ctx,
+		`INSERT INTO idempotency_keys(scope, key, fingerprint, created_at, expires_at, locked_until)
+		VALUES($1,$2,$3,$4,$5,$6)
+		ON CONFLICT (scope, key) DO UPDATE
+			SET fingerprint=EXCLUDED.fingerprint, created_at=EXCLUDED.created_at, expires_at=EXCLUDED.expires_at,
+				locked_until=EXCLUDED.locked_until, completed=FALSE, status_code=NULL, content_type=NULL, body=NULL
+			WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+				OR (NOT idempotency_keys.completed
+					AND idempotency_keys.fingerprint = EXCLUDED.fingerprint
+					AND idempotency_keys.locked_until <= EXCLUDED.created_at)
+		RETURNING TRUE`,
+		rec.Scope, rec.Key, rec.Fingerprint, rec.CreatedAt, rec.ExpiresAt, rec.LockedUntil).Scan(&reserved)
+	if err == nil {
+		return nil, nil
+	}
+	if err != pgx.ErrNoRows {
+		return nil, err
+	}
+
+	var existing idempotency.Record
+	err = r.conn.QueryRow(ctx,
+		`SELECT scope, key, fingerprint, completed, COALESCE(status_code, 0), COALESCE(content_type, ''),
+			COALESCE(body, ''::bytea), created_at, expires_at, COALESCE(locked_until, created_at)
+		FROM idempotency_keys WHERE scope=$1 AND key=$2`, rec.Scope, rec.Key).
+		Scan(&existing.Scope, &existing.Key, &existing.Fingerprint, &existing.Completed, &existing.StatusCode,
+			&existing.ContentType, &existing.Body, &existing.CreatedAt, &existing.ExpiresAt, &existing.LockedUntil)
+	if err == pgx.ErrNoRows {
+		// released between the two statements
+		return nil, idempotency.ErrRequestInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+func (r *PostgresIdempotencyRepo) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	_, err := r.conn.Exec(ctx,
+		"UPDATE idempotency_keys SET completed=TRUE, status_code=$1, content_type=$2, body=$3 WHERE scope=$4 AND key=$5",
+		statusCode, contentType, body, scope, key)
+	return err
+}
+
+func (r *PostgresIdempotencyRepo) Release(ctx context.Context, scope, key string) error {
+	_, err := r.conn.Exec(ctx,
+		"DELETE FROM idempotency_keys WHERE scope=$1 AND key=$2 AND NOT completed", scope, key)
+	return err
+}
+
+func (r *PostgresIdempotencyRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.conn.Exec(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= $1", now)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
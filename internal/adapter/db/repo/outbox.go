@@ -0,0 +1,305 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type outboxReception struct {
+	ID           string     `json:"id"`
+	PVZID        string     `json:"pvzId"`
+	StartedAt    time.Time  `json:"startedAt"`
+	Status       string     `json:"status"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+	OpenedBy     string     `json:"openedBy,omitempty"`
+	ClosedBy     string     `json:"closedBy,omitempty"`
+	ProductCount int        `json:"productCount,omitempty"`
+}
+
+type outboxProduct struct {
+	ID                   string    `json:"id"`
+	ReceptionID          string    `json:"receptionId"`
+	AddedAt              time.Time `json:"addedAt"`
+	Type                 string    `json:"type"`
+	Barcode              string    `json:"barcode,omitempty"`
+	SKU                  string    `json:"sku,omitempty"`
+	WeightGrams          int       `json:"weightGrams,omitempty"`
+	DeclaredValueKopecks int64     `json:"declaredValueKopecks,omitempty"`
+}
+
+type outboxPayload struct {
+	ID         string           `json:"id"`
+	Type       string           `json:"type"`
+	PVZID      string           `json:"pvzId"`
+	City       string           `json:"city"`
+	OccurredAt time.Time        `json:"occurredAt"`
+	Reception  *outboxReception `json:"reception,omitempty"`
+	Product    *outboxProduct   `json:"product,omitempty"`
+}
+
+func encodeEvent(e event.DomainEvent) ([]byte, error) {
+	p := outboxPayload{
+		ID:         e.ID,
+		Type:       string(e.Type),
+		PVZID:      e.PVZID,
+		City:       e.City,
+		OccurredAt: e.OccurredAt,
+	}
+	if e.Reception != nil {
+		p.Reception = &outboxReception{
+			ID:        e.Reception.ID,
+			PVZID:     e.Reception.PVZID,
+			StartedAt: e.Reception.StartedAt,
+			Status:    e.Reception.Status,
+
+			ClosedAt:     e.Reception.ClosedAt,
+			OpenedBy:     e.Reception.OpenedBy,
+			ClosedBy:     e.Reception.ClosedBy,
+			ProductCount: e.Reception.ProductCount,
+		}
+	}
+	if e.Product != nil {
+		p.Product = &outboxProduct{
+			ID:          e.Product.ID,
+			ReceptionID: e.Product.ReceptionID,
+			AddedAt:     e.Product.AddedAt,
+			Type:        e.Product.Type,
+
+			Barcode:              e.Product.Barcode,
+			SKU:                  e.Product.SKU,
+			WeightGrams:          e.Product.WeightGrams,
+			DeclaredValueKopecks: e.Product.DeclaredValueKopecks,
+		}
+	}
+	return json.Marshal(p)
+}
+
+func decodeEvent(data []byte) (event.DomainEvent, error) {
+	var p outboxPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return event.DomainEvent{}, err
+	}
+	e := event.DomainEvent{
+		ID:         p.ID,
+		Type:       event.Type(p.Type),
+		PVZID:      p.PVZID,
+		City:       p.City,
+		OccurredAt: p.OccurredAt,
+	}
+	if p.Reception != nil {
+		e.Reception = &reception.Reception{
+			ID:        p.Reception.ID,
+			PVZID:     p.Reception.PVZID,
+			StartedAt: p.Reception.StartedAt,
+			Status:    p.Reception.Status,
+
+			ClosedAt:     p.Reception.ClosedAt,
+			OpenedBy:     p.Reception.OpenedBy,
+			ClosedBy:     p.Reception.ClosedBy,
+			ProductCount: p.Reception.ProductCount,
+		}
+	}
+	if p.Product != nil {
+		e.Product = &product.Product{
+			ID:          p.Product.ID,
+			ReceptionID: p.Product.ReceptionID,
+			AddedAt:     p.Product.AddedAt,
+			Type:        p.Product.Type,
+
+			Barcode:              p.Product.Barcode,
+			SKU:                  p.Product.SKU,
+			WeightGrams:          p.Product.WeightGrams,
+			DeclaredValueKopecks: p.Product.DeclaredValueKopecks,
+		}
+	}
+	return e, nil
+}
+
+type PostgresOutboxRepo struct {
+	conn interface {
+		Begin(context.Context) (pgx.Tx, error)
+		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+		Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+		QueryRow(context.Context, string, ...interface{}) pgx.Row
+		CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)
+	}
+}
+
+func NewOutboxRepo(conn interface {
+	Begin(context.Context) (pgx.Tx, error)
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)
+}) *PostgresOutboxRepo {
+	return &PostgresOutboxRepo{conn: conn}
+}
+
+// outboxSequencerLockKey lets one sequencer at a time number the outbox; see
+// migration 022. Writers never take it.
+const outboxSequencerLockKey = 0x6f7574626f78
+
+func (r *PostgresOutboxRepo) Add(ctx context.Context, e event.DomainEvent) error {
+	payload, err := encodeEvent(e)
+	if err != nil {
+		return err
+	}
+	_, err = r.conn.Exec(ctx,
+		"INSERT INTO outbox(id, event_type, payload, status, created_at, next_attempt_at) VALUES($1,$2,$3,$4,$5,$5)",
+		e.ID, string(e.Type), payload, ports.OutboxStatusPending, e.OccurredAt)
+	return err
+}
+
+// AddMany writes the events in one round trip, in order.
+func (r *PostgresOutboxRepo) AddMany(ctx context.Context, events []event.DomainEvent) error {
+	rows := make([][]any, 0, len(events))
+	for _, e := range events {
+		payload, err := encodeEvent(e)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, []any{e.ID, string(e.Type), payload, ports.OutboxStatusPending, e.OccurredAt, e.OccurredAt})
+	}
+	_, err := r.conn.CopyFrom(ctx, pgx.Identifier{"outbox"},
+		[]string{"id", "event_type", "payload", "status", "created_at", "next_attempt_at"},
+		pgx.CopyFromRows(rows))
+	return err
+}
+
+func (r *PostgresOutboxRepo) ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]ports.OutboxMessage, error) {
+	rows, err := r.conn.Query(ctx,
+		`WITH claimed AS (
+			UPDATE outbox SET next_attempt_at=$3
+			WHERE id IN (
+				SELECT id FROM outbox
+				WHERE status=$1 AND next_attempt_at <= $2
+				ORDER BY created_at
+				LIMIT $4
+				FOR UPDATE SKIP LOCKED)
+			RETURNING id, payload, attempts, created_at)
+		SELECT id, payload, attempts FROM claimed ORDER BY created_at`,
+		ports.OutboxStatusPending, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var msgs []ports.OutboxMessage
+	for rows.Next() {
+		var (
+			payload []byte
+			msg     ports.OutboxMessage
+		)
+		if err := rows.Scan(&msg.ID, &payload, &msg.Attempts); err != nil {
+			return nil, err
+		}
+		msg.Event, msg.DecodeErr = decodeEvent(payload)
+		msgs = append(msgs, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// sequence numbers the rows written by transactions older than any still
+// running, in the order they were written. A sequencer commits before the next
+// one may start, so seq values become visible in increasing order and a reader
+// following them never skips a row. When another process is numbering, the
+// rows are left to it.
+func (r *PostgresOutboxRepo) sequence(ctx context.Context) error {
+	tx, err := r.conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var locked bool
+	if err := tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", int64(outboxSequencerLockKey)).Scan(&locked); err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE outbox o SET seq = s.seq
+		FROM (
+			SELECT id, nextval('outbox_seq') AS seq
+			FROM (
+				SELECT id FROM outbox
+				WHERE seq IS NULL AND xid < pg_snapshot_xmin(pg_current_snapshot())
+				ORDER BY xid, insert_order
+			) pending
+		) s
+		WHERE o.id = s.id`); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListAfter numbers what has committed since the last call, then lists.
+func (r *PostgresOutboxRepo) ListAfter(ctx context.Context, afterSequence uint64, limit int) ([]ports.OutboxMessage, error) {
+	if err := r.sequence(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := r.conn.Query(ctx,
+		"SELECT id, seq, payload, attempts FROM outbox WHERE seq > $1 ORDER BY seq LIMIT $2",
+		int64(afterSequence), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var msgs []ports.OutboxMessage
+	for rows.Next() {
+		var (
+			payload []byte
+			seq     int64
+			msg     ports.OutboxMessage
+		)
+		if err := rows.Scan(&msg.ID, &seq, &payload, &msg.Attempts); err != nil {
+			return nil, err
+		}
+		msg.Sequence = uint64(seq)
+		msg.Event, msg.DecodeErr = decodeEvent(payload)
+		msgs = append(msgs, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+func (r *PostgresOutboxRepo) LastSequence(ctx context.Context) (uint64, error) {
+	if err := r.sequence(ctx); err != nil {
+		return 0, err
+	}
+	var seq int64
+	err := r.conn.QueryRow(ctx, "SELECT COALESCE(max(seq), 0) FROM outbox").Scan(&seq)
+	return uint64(seq), err
+}
+
+func (r *PostgresOutboxRepo) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	_, err := r.conn.Exec(ctx,
+		"UPDATE outbox SET status=$1, delivered_at=$2, attempts=attempts+1, last_error=NULL WHERE id=$3",
+		ports.OutboxStatusDelivered, at, id)
+	return err
+}
+
+func (r *PostgresOutboxRepo) MarkFailed(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error {
+	status := ports.OutboxStatusPending
+	if dead {
+		status = ports.OutboxStatusDead
+	}
+	_, err := r.conn.Exec(ctx,
+		"UPDATE outbox SET status=$1, attempts=$2, next_attempt_at=$3, last_error=$4 WHERE id=$5",
+		status, attempts, nextAttemptAt, lastError, id)
+	return err
+}
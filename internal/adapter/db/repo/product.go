@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"pvz-service/internal/domain/product"
 
@@ -9,11 +10,23 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const (
+	productColumns = `p.id, p.reception_id, p.added_at, p.type, COALESCE(p.barcode, ''), COALESCE(p.sku, ''),
+		COALESCE(p.weight_grams, 0), COALESCE(p.declared_value_kopecks, 0)`
+	productsReceptionBarcodeKey = "products_reception_barcode_key"
+)
+
+func scanProduct(row pgx.Row, pr *product.Product) error {
+	return row.Scan(&pr.ID, &pr.ReceptionID, &pr.AddedAt, &pr.Type,
+		&pr.Barcode, &pr.SKU, &pr.WeightGrams, &pr.DeclaredValueKopecks)
+}
+
 type PostgresProductRepo struct {
 	conn interface {
 		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
 		QueryRow(context.Context, string, ...interface{}) pgx.Row
 		Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+		CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)
 	}
 }
 
@@ -21,23 +34,69 @@ func NewProductRepo(conn interface {
 	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
 	QueryRow(context.Context, string, ...interface{}) pgx.Row
 	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error)
 }) *PostgresProductRepo {
 	return &PostgresProductRepo{conn: conn}
 }
 
 func (r *PostgresProductRepo) Create(ctx context.Context, pr *product.Product) error {
 	_, err := r.conn.Exec(ctx,
-		"INSERT INTO products(id, reception_id, added_at, type) VALUES($1,$2,$3,$4)",
-		pr.ID, pr.ReceptionID, pr.AddedAt, pr.Type)
+		`INSERT INTO products(id, reception_id, added_at, type, barcode, sku, weight_grams, declared_value_kopecks)
+		VALUES($1,$2,$3,$4,NULLIF($5,''),NULLIF($6,''),NULLIF($7,0),NULLIF($8,0))`,
+		pr.ID, pr.ReceptionID, pr.AddedAt, pr.Type, pr.Barcode, pr.SKU, pr.WeightGrams, pr.DeclaredValueKopecks)
+	if isUniqueViolation(err, productsReceptionBarcodeKey) {
+		return product.ErrDuplicateBarcode
+	}
 	return err
 }
 
+// CreateMany inserts all products with a single COPY. Unlike Create it cannot
+// tell which row broke a constraint, so callers validate beforehand.
+func (r *PostgresProductRepo) CreateMany(ctx context.Context, products []*product.Product) error {
+	rows := make([][]any, 0, len(products))
+	for _, pr := range products {
+		rows = append(rows, []any{
+			pr.ID, pr.ReceptionID, pr.AddedAt, pr.Type,
+			nullIfZero(pr.Barcode), nullIfZero(pr.SKU), nullIfZero(pr.WeightGrams), nullIfZero(pr.DeclaredValueKopecks),
+		})
+	}
+	_, err := r.conn.CopyFrom(ctx, pgx.Identifier{"products"},
+		[]string{"id", "reception_id", "added_at", "type", "barcode", "sku", "weight_grams", "declared_value_kopecks"},
+		pgx.CopyFromRows(rows))
+	if isUniqueViolation(err, productsReceptionBarcodeKey) {
+		return product.ErrDuplicateBarcode
+	}
+	return err
+}
+
+func nullIfZero[T comparable](v T) any {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return v
+}
+
+func (r *PostgresProductRepo) Get(ctx context.Context, id string) (*product.Product, error) {
+	row := r.conn.QueryRow(ctx,
+		"SELECT "+productColumns+" FROM products p WHERE p.id=$1 AND p.deleted_at IS NULL", id)
+	var pr product.Product
+	err := scanProduct(row, &pr)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
 func (r *PostgresProductRepo) GetLastByReception(ctx context.Context, receptionID string) (*product.Product, error) {
 	row := r.conn.QueryRow(ctx,
-		"SELECT id, reception_id, added_at, type FROM products WHERE reception_id=$1 ORDER BY added_at DESC LIMIT 1",
+		"SELECT "+productColumns+" FROM products p WHERE p.reception_id=$1 AND p.deleted_at IS NULL ORDER BY p.added_at DESC, p.seq DESC LIMIT 1",
 		receptionID)
 	var pr product.Product
-	err := row.Scan(&pr.ID, &pr.ReceptionID, &pr.AddedAt, &pr.Type)
+	err := scanProduct(row, &pr)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -47,30 +106,66 @@ func (r *PostgresProductRepo) GetLastByReception(ctx context.Context, receptionI
 	return &pr, nil
 }
 
-func (r *PostgresProductRepo) Delete(ctx context.Context, productID string) error {
-	_, err := r.conn.Exec(ctx,
-		"DELETE FROM products WHERE id=$1", productID)
-	return err
+func (r *PostgresProductRepo) CountByReception(ctx context.Context, receptionID string) (int, error) {
+	var n int
+	err := r.conn.QueryRow(ctx,
+		"SELECT count(*) FROM products WHERE reception_id=$1 AND deleted_at IS NULL", receptionID).Scan(&n)
+	return n, err
 }
 
-func (r *PostgresProductRepo) GetByReception(ctx context.Context, receptionID string) ([]product.Product, error) {
+func (r *PostgresProductRepo) CountByType(ctx context.Context, receptionID string) (map[string]int, error) {
 	rows, err := r.conn.Query(ctx,
-		"SELECT id, reception_id, added_at, type FROM products WHERE reception_id=$1 ORDER BY added_at",
-		receptionID)
+		"SELECT type, count(*) FROM products WHERE reception_id=$1 AND deleted_at IS NULL GROUP BY type", receptionID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var products []product.Product
+	counts := map[string]int{}
 	for rows.Next() {
-		var pr product.Product
-		if err := rows.Scan(&pr.ID, &pr.ReceptionID, &pr.AddedAt, &pr.Type); err != nil {
+		var (
+			productType string
+			n           int
+		)
+		if err := rows.Scan(&productType, &n); err != nil {
 			return nil, err
 		}
-		products = append(products, pr)
+		counts[productType] = n
 	}
-	if err := rows.Err(); err != nil {
+	return counts, rows.Err()
+}
+
+// ExistingBarcodes reports which of the barcodes are already in the reception.
+func (r *PostgresProductRepo) ExistingBarcodes(ctx context.Context, receptionID string, barcodes []string) (map[string]bool, error) {
+	rows, err := r.conn.Query(ctx,
+		"SELECT barcode FROM products WHERE reception_id=$1 AND barcode = ANY($2) AND deleted_at IS NULL",
+		receptionID, barcodes)
+	if err != nil {
 		return nil, err
 	}
-	return products, nil
+	defer rows.Close()
+	existing := map[string]bool{}
+	for rows.Next() {
+		var barcode string
+		if err := rows.Scan(&barcode); err != nil {
+			return nil, err
+		}
+		existing[barcode] = true
+	}
+	return existing, rows.Err()
+}
+
+func (r *PostgresProductRepo) CountByReceptionAndType(ctx context.Context, receptionID, productType string) (int, error) {
+	var n int
+	err := r.conn.QueryRow(ctx,
+		"SELECT count(*) FROM products WHERE reception_id=$1 AND type=$2 AND deleted_at IS NULL",
+		receptionID, productType).Scan(&n)
+	return n, err
+}
+
+// Delete marks the product as removed; the row is kept for the audit trail.
+func (r *PostgresProductRepo) Delete(ctx context.Context, productID, deletedBy string, at time.Time) error {
+	_, err := r.conn.Exec(ctx,
+		"UPDATE products SET deleted_at=$1, deleted_by=NULLIF($2,'') WHERE id=$3 AND deleted_at IS NULL",
+		at, deletedBy, productID)
+	return err
 }
@@ -0,0 +1,87 @@
+package repo
+
+import (
+	"context"
+
+	"pvz-service/internal/domain/product"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	productTypesPKey = "product_types_pkey"
+	productsTypeFKey = "products_type_fkey"
+)
+
+type PostgresProductTypeRepo struct {
+	conn interface {
+		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+		Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	}
+}
+
+func NewProductTypeRepo(conn interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+}) *PostgresProductTypeRepo {
+	return &PostgresProductTypeRepo{conn: conn}
+}
+
+func (r *PostgresProductTypeRepo) List(ctx context.Context) ([]product.Type, error) {
+	rows, err := r.conn.Query(ctx,
+		"SELECT code, names, active, created_at FROM product_types ORDER BY code")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var types []product.Type
+	for rows.Next() {
+		var t product.Type
+		if err := rows.Scan(&t.Code, &t.Names, &t.Active, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+func (r *PostgresProductTypeRepo) Create(ctx context.Context, t *product.Type) error {
+	_, err := r.conn.Exec(ctx,
+		"INSERT INTO product_types(code, names, active, created_at) VALUES($1,$2,$3,$4)",
+		t.Code, t.Names, t.Active, t.CreatedAt)
+	if isUniqueViolation(err, productTypesPKey) {
+		return product.ErrTypeExists
+	}
+	return err
+}
+
+func (r *PostgresProductTypeRepo) Update(ctx context.Context, t *product.Type) error {
+	tag, err := r.conn.Exec(ctx,
+		"UPDATE product_types SET names=$1, active=$2 WHERE code=$3",
+		t.Names, t.Active, t.Code)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return product.ErrTypeNotFound
+	}
+	return nil
+}
+
+func (r *PostgresProductTypeRepo) Delete(ctx context.Context, code string) error {
+	tag, err := r.conn.Exec(ctx, "DELETE FROM product_types WHERE code=$1", code)
+	if isForeignKeyViolation(err, productsTypeFKey) {
+		return product.ErrTypeInUse
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return product.ErrTypeNotFound
+	}
+	return nil
+}
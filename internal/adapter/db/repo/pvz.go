@@ -6,12 +6,59 @@ import (
 	"strings"
 	"time"
 
+	"pvz-service/internal/domain/product"
 	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/usecase/ports"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const pvzTypeQuotasTypeFKey = "pvz_type_quotas_product_type_fkey"
+
+const pvzColumns = `p.id, p.city, p.timezone, p.created_at, COALESCE(p.stale_reception_after_seconds, 0),
+	p.name, p.address, p.working_hours, p.decommissioned_at, p.latitude, p.longitude`
+
+type scheduleJSON map[string]struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+func toScheduleJSON(s pvz.Schedule) scheduleJSON {
+	out := make(scheduleJSON, len(s))
+	for day, h := range s {
+		out[day] = struct {
+			Open  string `json:"open"`
+			Close string `json:"close"`
+		}{Open: h.Open, Close: h.Close}
+	}
+	return out
+}
+
+// scanPVZ reads pvzColumns followed by the extra destinations, if any.
+func scanPVZ(row pgx.Row, pv *pvz.PVZ, extra ...any) error {
+	var (
+		staleSeconds int
+		hours        scheduleJSON
+		lat, lon     *float64
+	)
+	dest := append([]any{&pv.ID, &pv.City, &pv.Timezone, &pv.CreatedAt, &staleSeconds,
+		&pv.Name, &pv.Address, &hours, &pv.DecommissionedAt, &lat, &lon}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+	if lat != nil && lon != nil {
+		pv.Coordinates = &pvz.Coordinates{Latitude: *lat, Longitude: *lon}
+	}
+	pv.StaleReceptionAfter = time.Duration(staleSeconds) * time.Second
+	pv.WorkingHours = make(pvz.Schedule, len(hours))
+	for day, h := range hours {
+		pv.WorkingHours[day] = pvz.OpeningHours{Open: h.Open, Close: h.Close}
+	}
+	return nil
+}
+
 type PostgresPVZRepo struct {
 	conn interface {
 		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
@@ -30,16 +77,46 @@ func NewPVZRepo(conn interface {
 
 func (r *PostgresPVZRepo) Create(ctx context.Context, p *pvz.PVZ) error {
 	_, err := r.conn.Exec(ctx,
-		"INSERT INTO pvzs(id, city, created_at) VALUES($1,$2,$3)",
-		p.ID, p.City, p.CreatedAt)
+		`INSERT INTO pvzs(id, city, timezone, created_at, stale_reception_after_seconds, name, address, working_hours, latitude, longitude)
+		VALUES($1,$2,$3,$4,NULLIF($5,0),$6,$7,$8,$9,$10)`,
+		p.ID, p.City, p.Timezone, p.CreatedAt, int(p.StaleReceptionAfter/time.Second),
+		p.Name, p.Address, toScheduleJSON(p.WorkingHours), latitude(p.Coordinates), longitude(p.Coordinates))
 	return err
 }
 
+func latitude(c *pvz.Coordinates) *float64 {
+	if c == nil {
+		return nil
+	}
+	return &c.Latitude
+}
+
+func longitude(c *pvz.Coordinates) *float64 {
+	if c == nil {
+		return nil
+	}
+	return &c.Longitude
+}
+
+// Update saves the editable details and the decommission mark.
+func (r *PostgresPVZRepo) Update(ctx context.Context, p *pvz.PVZ) error {
+	tag, err := r.conn.Exec(ctx,
+		"UPDATE pvzs SET name=$1, address=$2, working_hours=$3, decommissioned_at=$4, latitude=$5, longitude=$6 WHERE id=$7",
+		p.Name, p.Address, toScheduleJSON(p.WorkingHours), p.DecommissionedAt, latitude(p.Coordinates), longitude(p.Coordinates), p.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pvz.ErrNotFound
+	}
+	return nil
+}
+
 func (r *PostgresPVZRepo) Get(ctx context.Context, id string) (*pvz.PVZ, error) {
 	row := r.conn.QueryRow(ctx,
-		"SELECT id, city, created_at FROM pvzs WHERE id=$1", id)
+		"SELECT "+pvzColumns+" FROM pvzs p WHERE p.id=$1", id)
 	var pv pvz.PVZ
-	err := row.Scan(&pv.ID, &pv.City, &pv.CreatedAt)
+	err := scanPVZ(row, &pv)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -49,55 +126,234 @@ func (r *PostgresPVZRepo) Get(ctx context.Context, id string) (*pvz.PVZ, error)
 	return &pv, nil
 }
 
-func (r *PostgresPVZRepo) List(ctx context.Context, from, to *time.Time, limit, offset int) ([]pvz.PVZ, error) {
-	var (
-		rows pgx.Rows
-		err  error
-	)
-	if from == nil && to == nil {
-		query := "SELECT p.id, p.city, p.created_at FROM pvzs p ORDER BY p.created_at"
-		args := []any{}
-		if limit > 0 {
-			args = append(args, limit, offset)
-			query = fmt.Sprintf("%s LIMIT $1 OFFSET $2", query)
+func (r *PostgresPVZRepo) GetForUpdate(ctx context.Context, id string) (*pvz.PVZ, error) {
+	row := r.conn.QueryRow(ctx,
+		"SELECT "+pvzColumns+" FROM pvzs p WHERE p.id=$1 FOR UPDATE", id)
+	var pv pvz.PVZ
+	err := scanPVZ(row, &pv)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
 		}
-		rows, err = r.conn.Query(ctx, query, args...)
-	} else {
-		query := "SELECT DISTINCT p.id, p.city, p.created_at FROM pvzs p JOIN receptions r ON p.id = r.pvz_id"
-		args := []any{}
-		whereParts := []string{}
-		if from != nil {
-			args = append(args, *from)
-			whereParts = append(whereParts, fmt.Sprintf("r.started_at >= $%d", len(args)))
+		return nil, err
+	}
+	return &pv, nil
+}
+
+func (r *PostgresPVZRepo) GetCapacity(ctx context.Context, pvzID string) (pvz.Capacity, error) {
+	c := pvz.Capacity{MaxPerType: map[string]int{}}
+	err := r.conn.QueryRow(ctx,
+		"SELECT COALESCE(max_products_per_reception, 0) FROM pvzs WHERE id=$1", pvzID).Scan(&c.MaxProducts)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return c, pvz.ErrNotFound
 		}
-		if to != nil {
-			args = append(args, *to)
-			whereParts = append(whereParts, fmt.Sprintf("r.started_at <= $%d", len(args)))
+		return c, err
+	}
+	rows, err := r.conn.Query(ctx,
+		"SELECT product_type, max_products FROM pvz_type_quotas WHERE pvz_id=$1", pvzID)
+	if err != nil {
+		return c, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			code string
+			n    int
+		)
+		if err := rows.Scan(&code, &n); err != nil {
+			return c, err
 		}
-		if len(whereParts) > 0 {
-			query += " WHERE " + strings.Join(whereParts, " AND ")
+		c.MaxPerType[code] = n
+	}
+	return c, rows.Err()
+}
+
+// SetCapacity replaces all limits of the PVZ; run it inside a transaction.
+func (r *PostgresPVZRepo) SetCapacity(ctx context.Context, pvzID string, c pvz.Capacity) error {
+	tag, err := r.conn.Exec(ctx,
+		"UPDATE pvzs SET max_products_per_reception=NULLIF($1,0) WHERE id=$2", c.MaxProducts, pvzID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pvz.ErrNotFound
+	}
+	if _, err := r.conn.Exec(ctx, "DELETE FROM pvz_type_quotas WHERE pvz_id=$1", pvzID); err != nil {
+		return err
+	}
+	for code, n := range c.MaxPerType {
+		_, err := r.conn.Exec(ctx,
+			"INSERT INTO pvz_type_quotas(pvz_id, product_type, max_products) VALUES($1,$2,$3)", pvzID, code, n)
+		if isForeignKeyViolation(err, pvzTypeQuotasTypeFKey) {
+			return product.ErrInvalidType
 		}
-		query += " GROUP BY p.id, p.city, p.created_at ORDER BY p.created_at"
-		if limit > 0 {
-			args = append(args, limit, offset)
-			query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+		if err != nil {
+			return err
 		}
-		rows, err = r.conn.Query(ctx, query, args...)
 	}
+	return nil
+}
+
+// haversineKm is the great-circle distance from ($1, $2) to the PVZ p, in
+// kilometres. LEAST guards asin against rounding just above 1.
+const haversineKm = `2 * 6371.0088 * asin(LEAST(1, sqrt(
+		power(sin(radians(p.latitude - $1) / 2), 2) +
+		cos(radians($1)) * cos(radians(p.latitude)) * power(sin(radians(p.longitude - $2) / 2), 2))))`
+
+// Nearest returns active PVZs with known coordinates, closest first.
+func (r *PostgresPVZRepo) Nearest(ctx context.Context, q ports.NearestPVZQuery) ([]ports.PVZDistance, error) {
+	query := "SELECT * FROM (SELECT " + pvzColumns + ", " + haversineKm + ` AS distance_km
+		FROM pvzs p
+		WHERE p.latitude IS NOT NULL AND p.decommissioned_at IS NULL) p`
+	args := []any{q.From.Latitude, q.From.Longitude}
+	if q.RadiusKm > 0 {
+		args = append(args, q.RadiusKm)
+		query += fmt.Sprintf(" WHERE p.distance_km <= $%d", len(args))
+	}
+	args = append(args, q.Limit)
+	query += fmt.Sprintf(" ORDER BY p.distance_km, p.id LIMIT $%d", len(args))
+
+	rows, err := r.conn.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	result := []pvz.PVZ{}
+	var result []ports.PVZDistance
 	for rows.Next() {
-		var pv pvz.PVZ
-		if err := rows.Scan(&pv.ID, &pv.City, &pv.CreatedAt); err != nil {
+		var d ports.PVZDistance
+		if err := scanPVZ(rows, &d.PVZ, &d.DistanceKm); err != nil {
 			return nil, err
 		}
-		result = append(result, pv)
+		result = append(result, d)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result, rows.Err()
+}
+
+func pvzListQuery(filter ports.PVZListFilter) (string, []any) {
+	query := "SELECT " + pvzColumns + " FROM pvzs p"
+	args := []any{}
+	whereParts := []string{}
+	if !filter.IncludeDecommissioned {
+		whereParts = append(whereParts, "p.decommissioned_at IS NULL")
+	}
+	if filter.City != "" {
+		args = append(args, filter.City)
+		whereParts = append(whereParts, fmt.Sprintf("lower(p.city) = lower($%d)", len(args)))
+	}
+	if filter.HasOpenReception != nil {
+		args = append(args, []string{reception.StatusInProgress, reception.StatusReopened})
+		open := fmt.Sprintf(`EXISTS (SELECT 1 FROM receptions o
+			WHERE o.pvz_id = p.id AND o.deleted_at IS NULL AND o.status = ANY($%d::text[]))`, len(args))
+		if !*filter.HasOpenReception {
+			open = "NOT " + open
+		}
+		whereParts = append(whereParts, open)
+	}
+	if filter.DateField == ports.DateFieldPVZ {
+		var conds []string
+		conds, args = timeConds("p.created_at", filter, args)
+		whereParts = append(whereParts, conds...)
+	}
+	if filter.HasReceptionFilter() && !filter.IncludeEmpty {
+		query = "SELECT DISTINCT " + pvzColumns + " FROM pvzs p JOIN receptions r ON p.id = r.pvz_id AND r.deleted_at IS NULL"
+		var conds []string
+		conds, args = receptionConds(filter, args)
+		whereParts = append(whereParts, conds...)
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+		whereParts = append(whereParts, fmt.Sprintf("(p.created_at, p.id) > ($%d, $%d)", len(args)-1, len(args)))
+	}
+	if len(whereParts) > 0 {
+		query += " WHERE " + strings.Join(whereParts, " AND ")
+	}
+	query += " ORDER BY p.created_at, p.id"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+		if filter.After == nil {
+			args = append(args, filter.Offset)
+			query += fmt.Sprintf(" OFFSET $%d", len(args))
+		}
+	}
+	return query, args
+}
+
+// timeConds bounds column by the date filter. Local bounds are wall-clock
+// times interpreted in each PVZ's own timezone, so pvzs must be aliased as p.
+func timeConds(column string, filter ports.PVZListFilter, args []any) ([]string, []any) {
+	var conds []string
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conds = append(conds, fmt.Sprintf("%s >= $%d", column, len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conds = append(conds, fmt.Sprintf("%s <= $%d", column, len(args)))
+	}
+	if filter.LocalFrom != nil {
+		args = append(args, *filter.LocalFrom)
+		conds = append(conds, fmt.Sprintf("%s >= ($%d::timestamp AT TIME ZONE p.timezone)", column, len(args)))
+	}
+	if filter.LocalTo != nil {
+		args = append(args, *filter.LocalTo)
+		conds = append(conds, fmt.Sprintf("%s <= ($%d::timestamp AT TIME ZONE p.timezone)", column, len(args)))
+	}
+	return conds, args
+}
+
+// receptionConds expects receptions aliased as r and pvzs as p.
+func receptionConds(filter ports.PVZListFilter, args []any) ([]string, []any) {
+	var conds []string
+	switch filter.DateField {
+	case ports.DateFieldPVZ:
+		// applied to the PVZ itself
+	case ports.DateFieldProduct:
+		if filter.HasDateBounds() {
+			var bounds []string
+			bounds, args = timeConds("d.added_at", filter, args)
+			conds = append(conds, `EXISTS (SELECT 1 FROM products d
+			WHERE d.reception_id = r.id AND d.deleted_at IS NULL AND `+strings.Join(bounds, " AND ")+")")
+		}
+	default:
+		conds, args = timeConds("r.started_at", filter, args)
+	}
+	if filter.ClosedFrom != nil {
+		args = append(args, *filter.ClosedFrom)
+		conds = append(conds, fmt.Sprintf("r.closed_at >= $%d", len(args)))
+	}
+	if filter.ClosedTo != nil {
+		args = append(args, *filter.ClosedTo)
+		conds = append(conds, fmt.Sprintf("r.closed_at <= $%d", len(args)))
+	}
+	if filter.OpenedBy != "" {
+		args = append(args, filter.OpenedBy)
+		conds = append(conds, fmt.Sprintf("r.opened_by = $%d", len(args)))
+	}
+	if filter.ClosedBy != "" {
+		args = append(args, filter.ClosedBy)
+		conds = append(conds, fmt.Sprintf("r.closed_by = $%d", len(args)))
+	}
+	if len(filter.ReceptionStatuses) > 0 {
+		args = append(args, filter.ReceptionStatuses)
+		conds = append(conds, fmt.Sprintf("r.status = ANY($%d::text[])", len(args)))
+	}
+	if filter.ProductType != "" {
+		args = append(args, filter.ProductType)
+		conds = append(conds, fmt.Sprintf(`EXISTS (SELECT 1 FROM products t
+			WHERE t.reception_id = r.id AND t.deleted_at IS NULL AND t.type = $%d)`, len(args)))
+	}
+	if filter.MinProducts != nil || filter.MaxProducts != nil {
+		const count = "(SELECT count(*) FROM products c WHERE c.reception_id = r.id AND c.deleted_at IS NULL)"
+		if filter.MinProducts != nil {
+			args = append(args, *filter.MinProducts)
+			conds = append(conds, fmt.Sprintf("%s >= $%d", count, len(args)))
+		}
+		if filter.MaxProducts != nil {
+			args = append(args, *filter.MaxProducts)
+			conds = append(conds, fmt.Sprintf("%s <= $%d", count, len(args)))
+		}
 	}
-	return result, nil
+	return conds, args
 }
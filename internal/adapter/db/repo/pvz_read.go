@@ -0,0 +1,174 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type PostgresPVZReadModel struct {
+	conn interface {
+		Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	}
+}
+
+func NewPVZReadModel(conn interface {
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+}) *PostgresPVZReadModel {
+	return &PostgresPVZReadModel{conn: conn}
+}
+
+func (r *PostgresPVZReadModel) ListTrees(ctx context.Context, filter ports.PVZListFilter) ([]ports.PVZTree, error) {
+	query, args := pvzListQuery(filter)
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var trees []ports.PVZTree
+	for rows.Next() {
+		var pv pvz.PVZ
+		if err := scanPVZ(rows, &pv); err != nil {
+			return nil, err
+		}
+		trees = append(trees, ports.PVZTree{PVZ: pv})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(trees) == 0 {
+		return trees, nil
+	}
+
+	ids := make([]string, 0, len(trees))
+	byID := make(map[string]int, len(trees))
+	for i, t := range trees {
+		ids = append(ids, t.PVZ.ID)
+		byID[t.PVZ.ID] = i
+	}
+	if err := r.loadReceptions(ctx, trees, byID, ids, filter); err != nil {
+		return nil, err
+	}
+	return trees, nil
+}
+
+func (r *PostgresPVZReadModel) loadReceptions(ctx context.Context, trees []ports.PVZTree, byID map[string]int, ids []string, filter ports.PVZListFilter) error {
+	args := []any{ids}
+	productJoin := "pr.reception_id = r.id AND pr.deleted_at IS NULL"
+	if filter.DateField == ports.DateFieldProduct {
+		// only the products within the bounds are listed
+		var bounds []string
+		bounds, args = timeConds("pr.added_at", filter, args)
+		for _, b := range bounds {
+			productJoin += " AND " + b
+		}
+	}
+	query := "SELECT " + receptionColumns + `, COALESCE(lc.live, 0), pr.id, pr.added_at, pr.type,
+			COALESCE(pr.barcode, ''), COALESCE(pr.sku, ''), COALESCE(pr.weight_grams, 0), COALESCE(pr.declared_value_kopecks, 0)
+		FROM receptions r
+		JOIN pvzs p ON p.id = r.pvz_id
+		LEFT JOIN (
+			SELECT c.reception_id, COUNT(c.id) FILTER (WHERE c.deleted_at IS NULL) AS live
+			FROM products c
+			JOIN receptions cr ON cr.id = c.reception_id
+			WHERE cr.pvz_id = ANY($1::uuid[])
+			GROUP BY c.reception_id
+		) lc ON lc.reception_id = r.id
+		LEFT JOIN products pr ON ` + productJoin + `
+		WHERE r.pvz_id = ANY($1::uuid[]) AND r.deleted_at IS NULL`
+	conds, args := receptionConds(filter, args)
+	for _, c := range conds {
+		query += " AND " + c
+	}
+	query += " ORDER BY r.started_at, r.id, pr.added_at, pr.seq"
+
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			rec       reception.Reception
+			live      int
+			prodID    *string
+			prodAdded *time.Time
+			prodType  *string
+			details   product.Product
+		)
+		if err := rows.Scan(&rec.ID, &rec.PVZID, &rec.StartedAt, &rec.Status, &rec.ClosedAt,
+			&rec.OpenedBy, &rec.ClosedBy, &rec.ProductCount, &rec.ReopenedAt, &live, &prodID, &prodAdded, &prodType,
+			&details.Barcode, &details.SKU, &details.WeightGrams, &details.DeclaredValueKopecks); err != nil {
+			return err
+		}
+		if reception.IsOpen(rec.Status) {
+			// the stored total is only settled on close
+			rec.ProductCount = live
+		}
+		tree := &trees[byID[rec.PVZID]]
+		n := len(tree.Receptions)
+		if n == 0 || tree.Receptions[n-1].Reception.ID != rec.ID {
+			tree.Receptions = append(tree.Receptions, ports.ReceptionTree{Reception: rec})
+			n++
+		}
+		if prodID != nil {
+			tree.Receptions[n-1].Products = append(tree.Receptions[n-1].Products, product.Product{
+				ID:          *prodID,
+				AddedAt:     *prodAdded,
+				Type:        *prodType,
+				ReceptionID: rec.ID,
+
+				Barcode:              details.Barcode,
+				SKU:                  details.SKU,
+				WeightGrams:          details.WeightGrams,
+				DeclaredValueKopecks: details.DeclaredValueKopecks,
+			})
+		}
+	}
+	return rows.Err()
+}
+
+func (r *PostgresPVZReadModel) FindProductsByBarcode(ctx context.Context, barcode string) ([]ports.ProductLocation, error) {
+	rows, err := r.conn.Query(ctx,
+		"SELECT "+productColumns+", "+receptionColumns+`,
+			(SELECT count(*) FROM products c WHERE c.reception_id = r.id AND c.deleted_at IS NULL),
+			pv.id, pv.city, pv.timezone, pv.created_at
+		FROM products p
+		JOIN receptions r ON r.id = p.reception_id
+		JOIN pvzs pv ON pv.id = r.pvz_id
+		WHERE p.barcode = $1 AND p.deleted_at IS NULL AND r.deleted_at IS NULL
+		ORDER BY p.added_at DESC, p.seq DESC`, barcode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var result []ports.ProductLocation
+	for rows.Next() {
+		var (
+			loc  ports.ProductLocation
+			live int
+		)
+		pr, rec, pv := &loc.Product, &loc.Reception, &loc.PVZ
+		if err := rows.Scan(&pr.ID, &pr.ReceptionID, &pr.AddedAt, &pr.Type,
+			&pr.Barcode, &pr.SKU, &pr.WeightGrams, &pr.DeclaredValueKopecks,
+			&rec.ID, &rec.PVZID, &rec.StartedAt, &rec.Status, &rec.ClosedAt,
+			&rec.OpenedBy, &rec.ClosedBy, &rec.ProductCount, &rec.ReopenedAt, &live,
+			&pv.ID, &pv.City, &pv.Timezone, &pv.CreatedAt); err != nil {
+			return nil, err
+		}
+		if reception.IsOpen(rec.Status) {
+			rec.ProductCount = live
+		}
+		result = append(result, loc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -2,15 +2,26 @@ package repo
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/usecase/ports"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const (
+	receptionOneInProgressIndex = "receptions_one_in_progress_per_pvz"
+	receptionColumns            = `r.id, r.pvz_id, r.started_at, r.status, r.closed_at,
+		COALESCE(r.opened_by, ''), COALESCE(r.closed_by, ''), COALESCE(r.product_count, 0), r.reopened_at`
+)
+
+func scanReception(row pgx.Row, rec *reception.Reception) error {
+	return row.Scan(&rec.ID, &rec.PVZID, &rec.StartedAt, &rec.Status, &rec.ClosedAt,
+		&rec.OpenedBy, &rec.ClosedBy, &rec.ProductCount, &rec.ReopenedAt)
+}
+
 type PostgresReceptionRepo struct {
 	conn interface {
 		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
@@ -29,17 +40,34 @@ func NewReceptionRepo(conn interface {
 
 func (r *PostgresReceptionRepo) Create(ctx context.Context, rec *reception.Reception) error {
 	_, err := r.conn.Exec(ctx,
-		"INSERT INTO receptions(id, pvz_id, started_at, status) VALUES($1,$2,$3,$4)",
-		rec.ID, rec.PVZID, rec.StartedAt, rec.Status)
+		"INSERT INTO receptions(id, pvz_id, started_at, status, opened_by) VALUES($1,$2,$3,$4,NULLIF($5,''))",
+		rec.ID, rec.PVZID, rec.StartedAt, rec.Status, rec.OpenedBy)
+	if isUniqueViolation(err, receptionOneInProgressIndex) {
+		return reception.ErrReceptionAlreadyOpen
+	}
 	return err
 }
 
+func (r *PostgresReceptionRepo) Get(ctx context.Context, id string) (*reception.Reception, error) {
+	row := r.conn.QueryRow(ctx,
+		"SELECT "+receptionColumns+" FROM receptions r WHERE r.id=$1 AND r.deleted_at IS NULL", id)
+	var rec reception.Reception
+	err := scanReception(row, &rec)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rec, nil
+}
+
 func (r *PostgresReceptionRepo) GetOpenByPVZ(ctx context.Context, pvzID string) (*reception.Reception, error) {
 	row := r.conn.QueryRow(ctx,
-		"SELECT id, pvz_id, started_at, status FROM receptions WHERE pvz_id=$1 AND status=$2",
-		pvzID, reception.StatusInProgress)
+		"SELECT "+receptionColumns+" FROM receptions r WHERE r.pvz_id=$1 AND r.status = ANY($2) AND r.deleted_at IS NULL",
+		pvzID, []string{reception.StatusInProgress, reception.StatusReopened})
 	var rec reception.Reception
-	err := row.Scan(&rec.ID, &rec.PVZID, &rec.StartedAt, &rec.Status)
+	err := scanReception(row, &rec)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil
@@ -49,24 +77,74 @@ func (r *PostgresReceptionRepo) GetOpenByPVZ(ctx context.Context, pvzID string)
 	return &rec, nil
 }
 
-func (r *PostgresReceptionRepo) Close(ctx context.Context, receptionID string) error {
+// UpdateStatus persists a status transition together with its closing details.
+func (r *PostgresReceptionRepo) UpdateStatus(ctx context.Context, rec *reception.Reception) error {
+	var productCount *int
+	if rec.ClosedAt != nil {
+		productCount = &rec.ProductCount
+	}
 	_, err := r.conn.Exec(ctx,
-		"UPDATE receptions SET status=$1 WHERE id=$2", reception.StatusClosed, receptionID)
+		`UPDATE receptions SET status=$1, closed_at=$2, closed_by=NULLIF($3,''), product_count=$4, reopened_at=$6,
+			stale_flagged_at = CASE WHEN reopened_at IS DISTINCT FROM $6 THEN NULL ELSE stale_flagged_at END
+		WHERE id=$5`,
+		rec.Status, rec.ClosedAt, rec.ClosedBy, productCount, rec.ID, rec.ReopenedAt)
+	if isUniqueViolation(err, receptionOneInProgressIndex) {
+		return reception.ErrReceptionAlreadyOpen
+	}
 	return err
 }
 
-func (r *PostgresReceptionRepo) GetByPVZ(ctx context.Context, pvzID string, from, to *time.Time) ([]reception.Reception, error) {
-	query := "SELECT id, pvz_id, started_at, status FROM receptions WHERE pvz_id=$1"
-	args := []any{pvzID}
-	if from != nil {
-		args = append(args, *from)
-		query += fmt.Sprintf(" AND started_at >= $%d", len(args))
+func (r *PostgresReceptionRepo) ListStale(ctx context.Context, q ports.StaleReceptionQuery) ([]reception.Reception, error) {
+	query := "SELECT " + receptionColumns + ` FROM receptions r
+		JOIN pvzs p ON p.id = r.pvz_id
+		WHERE r.status = ANY($1) AND r.deleted_at IS NULL
+			AND COALESCE(r.reopened_at, r.started_at) <= $2 - make_interval(secs => COALESCE(p.stale_reception_after_seconds, $3))`
+	if q.UnflaggedOnly {
+		query += " AND r.stale_flagged_at IS NULL"
 	}
-	if to != nil {
-		args = append(args, *to)
-		query += fmt.Sprintf(" AND started_at <= $%d", len(args))
+	query += " ORDER BY COALESCE(r.reopened_at, r.started_at) LIMIT $4"
+	rows, err := r.conn.Query(ctx, query,
+		[]string{reception.StatusInProgress, reception.StatusReopened}, q.Now, int(q.DefaultMaxAge/time.Second), q.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var recs []reception.Reception
+	for rows.Next() {
+		var rec reception.Reception
+		if err := scanReception(rows, &rec); err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return recs, rows.Err()
+}
+
+func (r *PostgresReceptionRepo) MarkStale(ctx context.Context, receptionID string, at time.Time) (int64, error) {
+	tag, err := r.conn.Exec(ctx,
+		"UPDATE receptions SET stale_flagged_at=$1 WHERE id=$2 AND stale_flagged_at IS NULL", at, receptionID)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *PostgresReceptionRepo) Delete(ctx context.Context, receptionID, deletedBy string, at time.Time) error {
+	_, err := r.conn.Exec(ctx,
+		"UPDATE receptions SET deleted_at=$1, deleted_by=NULLIF($2,'') WHERE id=$3 AND deleted_at IS NULL",
+		at, deletedBy, receptionID)
+	return err
+}
+
+func (r *PostgresReceptionRepo) GetByPVZ(ctx context.Context, pvzID string, filter ports.PVZListFilter) ([]reception.Reception, error) {
+	query := "SELECT " + receptionColumns + ` FROM receptions r
+		JOIN pvzs p ON p.id = r.pvz_id
+		WHERE r.pvz_id=$1 AND r.deleted_at IS NULL`
+	conds, args := receptionConds(filter, []any{pvzID})
+	for _, c := range conds {
+		query += " AND " + c
 	}
-	query += " ORDER BY started_at"
+	query += " ORDER BY r.started_at"
 	rows, err := r.conn.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
@@ -75,7 +153,7 @@ func (r *PostgresReceptionRepo) GetByPVZ(ctx context.Context, pvzID string, from
 	var recs []reception.Reception
 	for rows.Next() {
 		var rec reception.Reception
-		if err := rows.Scan(&rec.ID, &rec.PVZID, &rec.StartedAt, &rec.Status); err != nil {
+		if err := scanReception(rows, &rec); err != nil {
 			return nil, err
 		}
 		recs = append(recs, rec)
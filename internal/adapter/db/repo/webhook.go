@@ -0,0 +1,234 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/domain/webhook"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	subscriptionColumns = "id, url, event_types, pvz_id, city, secret, active, consecutive_failures, created_at, disabled_at"
+	deliveryColumns     = "d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.delivered_at"
+)
+
+type PostgresWebhookRepo struct {
+	conn interface {
+		Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+		Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+		QueryRow(context.Context, string, ...interface{}) pgx.Row
+	}
+}
+
+func NewWebhookRepo(conn interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}) *PostgresWebhookRepo {
+	return &PostgresWebhookRepo{conn: conn}
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func scanSubscription(row pgx.Row) (*webhook.Subscription, error) {
+	var (
+		s          webhook.Subscription
+		eventTypes []string
+		pvzID      *string
+		city       *string
+	)
+	err := row.Scan(&s.ID, &s.URL, &eventTypes, &pvzID, &city, &s.Secret, &s.Active, &s.ConsecutiveFailures, &s.CreatedAt, &s.DisabledAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range eventTypes {
+		s.EventTypes = append(s.EventTypes, event.Type(t))
+	}
+	if pvzID != nil {
+		s.PVZID = *pvzID
+	}
+	if city != nil {
+		s.City = *city
+	}
+	return &s, nil
+}
+
+func (r *PostgresWebhookRepo) CreateSubscription(ctx context.Context, s *webhook.Subscription) error {
+	eventTypes := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+	_, err := r.conn.Exec(ctx,
+		"INSERT INTO webhook_subscriptions(id, url, event_types, pvz_id, city, secret, active, created_at) VALUES($1,$2,$3,$4,$5,$6,$7,$8)",
+		s.ID, s.URL, eventTypes, nullIfEmpty(s.PVZID), nullIfEmpty(s.City), s.Secret, s.Active, s.CreatedAt)
+	return err
+}
+
+func (r *PostgresWebhookRepo) GetSubscription(ctx context.Context, id string) (*webhook.Subscription, error) {
+	s, err := scanSubscription(r.conn.QueryRow(ctx,
+		"SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE id=$1", id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *PostgresWebhookRepo) ListSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	return r.listSubscriptions(ctx, "SELECT "+subscriptionColumns+" FROM webhook_subscriptions ORDER BY created_at")
+}
+
+func (r *PostgresWebhookRepo) ListActiveSubscriptions(ctx context.Context) ([]webhook.Subscription, error) {
+	return r.listSubscriptions(ctx, "SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE active ORDER BY created_at")
+}
+
+func (r *PostgresWebhookRepo) listSubscriptions(ctx context.Context, query string) ([]webhook.Subscription, error) {
+	rows, err := r.conn.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var subs []webhook.Subscription
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (r *PostgresWebhookRepo) DeleteSubscription(ctx context.Context, id string) (bool, error) {
+	tag, err := r.conn.Exec(ctx, "DELETE FROM webhook_subscriptions WHERE id=$1", id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PostgresWebhookRepo) RecordSuccess(ctx context.Context, subscriptionID string) error {
+	_, err := r.conn.Exec(ctx,
+		"UPDATE webhook_subscriptions SET consecutive_failures=0 WHERE id=$1", subscriptionID)
+	return err
+}
+
+func (r *PostgresWebhookRepo) RecordFailure(ctx context.Context, subscriptionID string, maxFailures int, at time.Time) error {
+	_, err := r.conn.Exec(ctx,
+		`UPDATE webhook_subscriptions SET
+			consecutive_failures = consecutive_failures + 1,
+			active = CASE WHEN consecutive_failures + 1 >= $2 THEN FALSE ELSE active END,
+			disabled_at = CASE WHEN consecutive_failures + 1 >= $2 AND active THEN $3 ELSE disabled_at END
+		WHERE id=$1`,
+		subscriptionID, maxFailures, at)
+	return err
+}
+
+func (r *PostgresWebhookRepo) CreateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	_, err := r.conn.Exec(ctx,
+		`INSERT INTO webhook_deliveries(id, subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+		VALUES($1,$2,$3,$4,$5,$6,$7,$8)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`,
+		d.ID, d.SubscriptionID, d.EventID, string(d.EventType), d.Payload, d.Status, d.NextAttemptAt, d.CreatedAt)
+	return err
+}
+
+func scanDelivery(row pgx.Row) (*webhook.Delivery, error) {
+	var (
+		d          webhook.Delivery
+		eventType  string
+		statusCode *int
+		lastError  *string
+	)
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventID, &eventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &statusCode, &lastError, &d.CreatedAt, &d.DeliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	d.EventType = event.Type(eventType)
+	if statusCode != nil {
+		d.LastStatusCode = *statusCode
+	}
+	if lastError != nil {
+		d.LastError = *lastError
+	}
+	return &d, nil
+}
+
+func (r *PostgresWebhookRepo) listDeliveries(ctx context.Context, query string, args ...any) ([]webhook.Delivery, error) {
+	rows, err := r.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var deliveries []webhook.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *PostgresWebhookRepo) ClaimPendingDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]webhook.Delivery, error) {
+	return r.listDeliveries(ctx,
+		`WITH claimed AS (
+			UPDATE webhook_deliveries SET next_attempt_at=$3
+			WHERE id IN (
+				SELECT d.id FROM webhook_deliveries d
+				JOIN webhook_subscriptions s ON s.id = d.subscription_id
+				WHERE d.status=$1 AND d.next_attempt_at <= $2 AND s.active
+				ORDER BY d.next_attempt_at
+				LIMIT $4
+				FOR UPDATE OF d SKIP LOCKED)
+			RETURNING *)
+		SELECT `+deliveryColumns+` FROM claimed d ORDER BY d.created_at`,
+		webhook.DeliveryPending, now, leaseUntil, limit)
+}
+
+func (r *PostgresWebhookRepo) ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]webhook.Delivery, error) {
+	return r.listDeliveries(ctx,
+		"SELECT "+deliveryColumns+" FROM webhook_deliveries d WHERE d.subscription_id=$1 ORDER BY d.created_at DESC LIMIT $2",
+		subscriptionID, limit)
+}
+
+func (r *PostgresWebhookRepo) MarkDelivered(ctx context.Context, id string, statusCode int, at time.Time) error {
+	_, err := r.conn.Exec(ctx,
+		"UPDATE webhook_deliveries SET status=$1, attempts=attempts+1, last_status_code=$2, last_error=NULL, delivered_at=$3 WHERE id=$4",
+		webhook.DeliveryDelivered, statusCode, at, id)
+	return err
+}
+
+func (r *PostgresWebhookRepo) MarkAttemptFailed(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, statusCode int, lastError string, failed bool) error {
+	status := webhook.DeliveryPending
+	if failed {
+		status = webhook.DeliveryFailed
+	}
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+	_, err := r.conn.Exec(ctx,
+		"UPDATE webhook_deliveries SET status=$1, attempts=$2, next_attempt_at=$3, last_status_code=$4, last_error=$5 WHERE id=$6",
+		status, attempts, nextAttemptAt, code, lastError, id)
+	return err
+}
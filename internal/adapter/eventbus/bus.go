@@ -0,0 +1,129 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/usecase/ports"
+)
+
+const (
+	DefaultHistorySize = 1024
+	subscriberBuffer   = 64
+)
+
+type subscriber struct {
+	ch     chan ports.SequencedEvent
+	filter func(event.DomainEvent) bool
+}
+
+// Bus fans sequenced events out to in-process subscribers and keeps the last
+// historySize of them so that subscribers can resume from the last sequence
+// they have seen. Sequences come from the publisher and must grow.
+type Bus struct {
+	mu          sync.Mutex
+	seq         uint64
+	history     []ports.SequencedEvent
+	historySize int
+	subs        map[*subscriber]struct{}
+}
+
+func New(historySize int) *Bus {
+	return &Bus{historySize: historySize, subs: make(map[*subscriber]struct{})}
+}
+
+// skipTo moves the bus past seq without history, e.g. to where a persistent
+// log ends when the process starts.
+func (b *Bus) skipTo(seq uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if seq > b.seq {
+		b.seq = seq
+		b.history = b.history[:0]
+	}
+}
+
+func (b *Bus) Publish(se ports.SequencedEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if se.Sequence <= b.seq {
+		return
+	}
+	b.seq = se.Sequence
+	b.history = append(b.history, se)
+	if len(b.history) > b.historySize {
+		b.history = append(b.history[:0], b.history[len(b.history)-b.historySize:]...)
+	}
+
+	for s := range b.subs {
+		if s.filter != nil && !s.filter(se.Event) {
+			continue
+		}
+		select {
+		case s.ch <- se:
+		default:
+			// Slow subscriber: drop it, the client resumes from its last sequence.
+			delete(b.subs, s)
+			close(s.ch)
+		}
+	}
+}
+
+// Subscribe replays the kept events after afterSequence and then follows new
+// ones; zero means new events only. ErrSequenceExpired is returned when
+// afterSequence is ahead of the bus or older than its history.
+func (b *Bus) Subscribe(ctx context.Context, afterSequence uint64, filter func(event.DomainEvent) bool) (<-chan ports.SequencedEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if afterSequence > b.seq {
+		return nil, ports.ErrSequenceExpired
+	}
+	if afterSequence > 0 && afterSequence < b.seq {
+		if len(b.history) == 0 || b.history[0].Sequence > afterSequence+1 {
+			return nil, ports.ErrSequenceExpired
+		}
+	}
+
+	var replay []ports.SequencedEvent
+	if afterSequence > 0 {
+		for _, se := range b.history {
+			if se.Sequence > afterSequence && (filter == nil || filter(se.Event)) {
+				replay = append(replay, se)
+			}
+		}
+	}
+	return b.attach(ctx, filter, replay), nil
+}
+
+// follow subscribes to events after the returned head.
+func (b *Bus) follow(ctx context.Context, filter func(event.DomainEvent) bool) (<-chan ports.SequencedEvent, uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.attach(ctx, filter, nil), b.seq
+}
+
+// attach expects b.mu to be held.
+func (b *Bus) attach(ctx context.Context, filter func(event.DomainEvent) bool, replay []ports.SequencedEvent) <-chan ports.SequencedEvent {
+	s := &subscriber{
+		ch:     make(chan ports.SequencedEvent, len(replay)+subscriberBuffer),
+		filter: filter,
+	}
+	for _, se := range replay {
+		s.ch <- se
+	}
+	b.subs[s] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[s]; ok {
+			delete(b.subs, s)
+			close(s.ch)
+		}
+	}()
+	return s.ch
+}
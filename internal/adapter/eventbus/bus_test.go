@@ -0,0 +1,164 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sequenced(seq uint64, pvzID string) ports.SequencedEvent {
+	return ports.SequencedEvent{Sequence: seq, Event: event.DomainEvent{Type: event.ProductAdded, PVZID: pvzID}}
+}
+
+func receive(t *testing.T, ch <-chan ports.SequencedEvent, n int) []uint64 {
+	t.Helper()
+	var got []uint64
+	for len(got) < n {
+		select {
+		case se, ok := <-ch:
+			require.True(t, ok, "stream closed after %v", got)
+			got = append(got, se.Sequence)
+		case <-time.After(time.Second):
+			t.Fatalf("got %v, want %d events", got, n)
+		}
+	}
+	return got
+}
+
+func TestBusResumesFromHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := New(10)
+	for seq := uint64(1); seq <= 3; seq++ {
+		b.Publish(sequenced(seq, "a"))
+	}
+
+	ch, err := b.Subscribe(ctx, 1, nil)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, receive(t, ch, 2))
+
+	b.Publish(sequenced(4, "a"))
+	b.Publish(sequenced(4, "a")) // already seen
+	b.Publish(sequenced(5, "a"))
+	require.Equal(t, []uint64{4, 5}, receive(t, ch, 2))
+}
+
+func TestBusHistoryOverflow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := New(3)
+	for seq := uint64(1); seq <= 5; seq++ {
+		b.Publish(sequenced(seq, "a"))
+	}
+
+	_, err := b.Subscribe(ctx, 1, nil)
+	require.ErrorIs(t, err, ports.ErrSequenceExpired, "event 2 has left the history")
+	_, err = b.Subscribe(ctx, 6, nil)
+	require.ErrorIs(t, err, ports.ErrSequenceExpired, "sequence from the future")
+
+	ch, err := b.Subscribe(ctx, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 4, 5}, receive(t, ch, 3))
+}
+
+func TestBusFiltersAndDropsSlowSubscribers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b := New(10)
+	onlyA := func(e event.DomainEvent) bool { return e.PVZID == "a" }
+	filtered, err := b.Subscribe(ctx, 0, onlyA)
+	require.NoError(t, err)
+	slow, err := b.Subscribe(ctx, 0, nil)
+	require.NoError(t, err)
+
+	b.Publish(sequenced(1, "b"))
+	b.Publish(sequenced(2, "a"))
+	require.Equal(t, []uint64{2}, receive(t, filtered, 1))
+
+	for seq := uint64(3); seq < 3+subscriberBuffer; seq++ {
+		b.Publish(sequenced(seq, "b"))
+	}
+	for range slow {
+	}
+	// the filtered subscriber saw none of those and is still attached
+	b.Publish(sequenced(3+subscriberBuffer, "a"))
+	require.Equal(t, []uint64{3 + subscriberBuffer}, receive(t, filtered, 1))
+}
+
+type memoryLog struct {
+	mu   sync.Mutex
+	msgs []ports.OutboxMessage
+}
+
+func (l *memoryLog) append(msg ports.OutboxMessage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, msg)
+}
+
+func (l *memoryLog) ListAfter(_ context.Context, afterSequence uint64, limit int) ([]ports.OutboxMessage, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []ports.OutboxMessage
+	for _, msg := range l.msgs {
+		if msg.Sequence > afterSequence && len(out) < limit {
+			out = append(out, msg)
+		}
+	}
+	return out, nil
+}
+
+func (l *memoryLog) LastSequence(context.Context) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.msgs) == 0 {
+		return 0, nil
+	}
+	return l.msgs[len(l.msgs)-1].Sequence, nil
+}
+
+func logged(seq uint64, pvzID string) ports.OutboxMessage {
+	se := sequenced(seq, pvzID)
+	return ports.OutboxMessage{Sequence: se.Sequence, Event: se.Event}
+}
+
+func TestFeedResumesFromLogAfterRestart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	log := &memoryLog{}
+	for seq := uint64(1); seq <= 5; seq++ {
+		log.append(logged(seq, "a"))
+	}
+	log.append(ports.OutboxMessage{Sequence: 7, DecodeErr: errors.New("bad payload")})
+	log.append(logged(8, "b"))
+	log.append(logged(9, "a"))
+
+	// a fresh process: nothing in memory, the log goes up to 9
+	feed := NewFeed(log, FeedConfig{PollInterval: time.Hour, BatchSize: 2, HistorySize: 2})
+	require.NoError(t, feed.Start(ctx))
+
+	_, err := feed.Subscribe(ctx, 10, nil)
+	require.ErrorIs(t, err, ports.ErrSequenceExpired)
+
+	ch, err := feed.Subscribe(ctx, 3, func(e event.DomainEvent) bool { return e.PVZID == "a" })
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4, 5, 9}, receive(t, ch, 3))
+
+	log.append(logged(10, "b"))
+	log.append(logged(11, "a"))
+	n, err := feed.Poll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []uint64{11}, receive(t, ch, 1))
+}
@@ -0,0 +1,137 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/usecase/ports"
+)
+
+type FeedConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	HistorySize  int
+}
+
+// Feed follows the event log shared by all processes and fans it out through
+// a Bus. Sequences are the log's own, so a client can resume after a restart
+// or against another instance; resumes older than the bus history are served
+// from the log.
+type Feed struct {
+	log  ports.EventLog
+	bus  *Bus
+	cfg  FeedConfig
+	last uint64
+}
+
+func NewFeed(log ports.EventLog, cfg FeedConfig) *Feed {
+	return &Feed{log: log, bus: New(cfg.HistorySize), cfg: cfg}
+}
+
+// Start positions the feed at the current end of the log. It has to be called
+// before subscribers are served.
+func (f *Feed) Start(ctx context.Context) error {
+	last, err := f.log.LastSequence(ctx)
+	if err != nil {
+		return err
+	}
+	f.last = last
+	f.bus.skipTo(last)
+	return nil
+}
+
+func (f *Feed) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := f.Poll(ctx)
+			if err != nil || n < f.cfg.BatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll publishes the next batch of the log and returns how many entries it read.
+func (f *Feed) Poll(ctx context.Context) (int, error) {
+	msgs, err := f.log.ListAfter(ctx, f.last, f.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, msg := range msgs {
+		f.last = msg.Sequence
+		if msg.DecodeErr != nil {
+			continue
+		}
+		f.bus.Publish(ports.SequencedEvent{Sequence: msg.Sequence, Event: msg.Event})
+	}
+	return len(msgs), nil
+}
+
+func (f *Feed) Subscribe(ctx context.Context, afterSequence uint64, filter func(event.DomainEvent) bool) (<-chan ports.SequencedEvent, error) {
+	ch, err := f.bus.Subscribe(ctx, afterSequence, filter)
+	if !errors.Is(err, ports.ErrSequenceExpired) {
+		return ch, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	live, head := f.bus.follow(ctx, filter)
+	if afterSequence > head {
+		cancel()
+		return nil, ports.ErrSequenceExpired
+	}
+
+	// Catch up from the log to head, then go on with what the bus has got
+	// meanwhile. If the bus drops us in the meantime the stream ends and the
+	// client resumes, as with any slow subscriber.
+	out := make(chan ports.SequencedEvent, subscriberBuffer)
+	go func() {
+		defer close(out)
+		defer cancel()
+		send := func(se ports.SequencedEvent) bool {
+			select {
+			case out <- se:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		cursor := afterSequence
+		for cursor < head {
+			msgs, err := f.log.ListAfter(ctx, cursor, f.cfg.BatchSize)
+			if err != nil || len(msgs) == 0 {
+				return
+			}
+			for _, msg := range msgs {
+				if msg.Sequence > head {
+					break
+				}
+				cursor = msg.Sequence
+				if msg.DecodeErr != nil || (filter != nil && !filter(msg.Event)) {
+					continue
+				}
+				if !send(ports.SequencedEvent{Sequence: msg.Sequence, Event: msg.Event}) {
+					return
+				}
+			}
+			if msgs[len(msgs)-1].Sequence > head {
+				break
+			}
+		}
+		for se := range live {
+			if !send(se) {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -13,6 +13,7 @@ var (
 	pvzCounter       = promauto.NewCounter(prometheus.CounterOpts{Name: "pvz_created_total", Help: "Total PVZ created"})
 	receptionCounter = promauto.NewCounter(prometheus.CounterOpts{Name: "receptions_created_total", Help: "Total receptions opened"})
 	productCounter   = promauto.NewCounter(prometheus.CounterOpts{Name: "products_added_total", Help: "Total products added"})
+	staleCounter     = promauto.NewCounterVec(prometheus.CounterOpts{Name: "receptions_stale_total", Help: "Stale receptions handled by the scheduler"}, []string{"action"})
 )
 
 type PromMetrics struct{}
@@ -40,3 +41,7 @@ func (m *PromMetrics) IncReceptionCreated() {
 func (m *PromMetrics) IncProductAdded() {
 	productCounter.Inc()
 }
+
+func (m *PromMetrics) IncStaleReception(action string) {
+	staleCounter.WithLabelValues(action).Inc()
+}
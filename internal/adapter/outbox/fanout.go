@@ -0,0 +1,25 @@
+package outbox
+
+import (
+	"context"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/usecase/ports"
+)
+
+type Fanout struct {
+	publishers []ports.OutboxPublisher
+}
+
+func NewFanout(publishers ...ports.OutboxPublisher) *Fanout {
+	return &Fanout{publishers: publishers}
+}
+
+func (f *Fanout) Publish(ctx context.Context, e event.DomainEvent) error {
+	for _, p := range f.publishers {
+		if err := p.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
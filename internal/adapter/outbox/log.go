@@ -0,0 +1,26 @@
+package outbox
+
+import (
+	"context"
+
+	"pvz-service/internal/adapter/observability/logging"
+	"pvz-service/internal/domain/event"
+
+	"go.uber.org/zap"
+)
+
+type LogPublisher struct{}
+
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, e event.DomainEvent) error {
+	logging.Logger.Info("domain event",
+		zap.String("id", e.ID),
+		zap.String("type", string(e.Type)),
+		zap.String("pvz_id", e.PVZID),
+		zap.Time("occurred_at", e.OccurredAt),
+	)
+	return nil
+}
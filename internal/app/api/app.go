@@ -15,13 +15,21 @@ import (
 	"pvz-service/internal/adapter/db/postgres"
 	"pvz-service/internal/adapter/observability/logging"
 	"pvz-service/internal/adapter/observability/metrics"
+	outboxad "pvz-service/internal/adapter/outbox"
 	clockad "pvz-service/internal/adapter/time"
+	webhookad "pvz-service/internal/adapter/webhook"
 	"pvz-service/internal/config"
 	"pvz-service/internal/transport/http/handler"
 	"pvz-service/internal/transport/http/middleware"
+	auditUC "pvz-service/internal/usecase/audit"
 	"pvz-service/internal/usecase/auth"
+	"pvz-service/internal/usecase/catalog"
+	"pvz-service/internal/usecase/city"
+	idempotencyUC "pvz-service/internal/usecase/idempotency"
+	outboxUC "pvz-service/internal/usecase/outbox"
 	pvzUC "pvz-service/internal/usecase/pvz"
 	recvUC "pvz-service/internal/usecase/reception"
+	webhookUC "pvz-service/internal/usecase/webhook"
 )
 
 type App struct {
@@ -29,6 +37,12 @@ type App struct {
 	httpServer    *http.Server
 	metricsServer *http.Server
 	db            *postgres.PostgresDB
+	outboxRelay   *outboxUC.Relay
+	webhookWorker *webhookUC.Dispatcher
+	staleSweeper  *recvUC.Sweeper
+	idempotency   *idempotencyUC.Service
+	workersCtx    context.Context
+	stopWorkers   context.CancelFunc
 }
 
 func New(cfg config.Config) (*App, error) {
@@ -44,16 +58,23 @@ func New(cfg config.Config) (*App, error) {
 	clock := clockad.RealClock{}
 
 	userRepo := db.UserRepo()
-	pvzRepo := db.PVZRepo()
-	receptionRepo := db.ReceptionRepo()
-	productRepo := db.ProductRepo()
+	pvzReadModel := db.PVZReadModel()
 
 	authService := auth.NewService(userRepo, tokenManager, passwordHasher, clock)
-	pvzService := pvzUC.NewService(pvzRepo, receptionRepo, productRepo, clock)
-	receptionService := recvUC.NewService(pvzRepo, receptionRepo, productRepo, clock)
+	cityService := city.NewService(db.CityRepo(), clock, city.DefaultCacheTTL)
+	pvzService := pvzUC.NewService(db, pvzReadModel, cityService, clock)
+	catalogService := catalog.NewService(db.ProductTypeRepo(), clock, catalog.DefaultCacheTTL)
+	receptionService := recvUC.NewService(db, catalogService, clock, cfg.Reception.ReopenWindow)
+
+	webhookService := webhookUC.NewService(db.WebhookRepo(), cityService, clock)
+	idempotencyService := idempotencyUC.NewService(db.IdempotencyRepo(), clock, cfg.Idempotency.TTL, cfg.Idempotency.Lease)
 
 	authHandler := handler.NewAuthHandler(authService)
-	pvzHandler := handler.NewPVZHandler(pvzService, receptionService)
+	pvzHandler := handler.NewPVZHandler(pvzService, receptionService, catalogService)
+	productTypeHandler := handler.NewProductTypeHandler(catalogService)
+	cityHandler := handler.NewCityHandler(cityService)
+	auditHandler := handler.NewAuditHandler(auditUC.NewService(db.AuditRepo()))
+	webhookHandler := handler.NewWebhookHandler(webhookService)
 
 	metricsCollector := metrics.NewPromMetrics()
 
@@ -61,8 +82,9 @@ func New(cfg config.Config) (*App, error) {
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins: []string{"http://localhost:8081", "http://127.0.0.1:8081"},
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", middleware.IdempotencyKeyHeader},
+		ExposedHeaders: []string{handler.NextCursorHeader, middleware.IdempotentReplayHeader},
 		MaxAge:         300,
 	}))
 
@@ -75,15 +97,46 @@ func New(cfg config.Config) (*App, error) {
 
 	r.Group(func(pr chi.Router) {
 		pr.Use(middleware.AuthMiddleware(tokenManager))
+		// after the role check, so a forbidden caller never reaches a stored response
+		idempotent := middleware.IdempotencyMiddleware(idempotencyService)
 
-		pr.With(middleware.RequireRole("moderator")).Post("/pvz", pvzHandler.CreatePVZ)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/pvz", pvzHandler.CreatePVZ)
 		pr.With(middleware.RequireRole("employee", "moderator")).Get("/pvz", pvzHandler.ListPVZ)
-
-		pr.With(middleware.RequireRole("employee")).Post("/receptions", pvzHandler.CreateReception)
-		pr.With(middleware.RequireRole("employee")).Post("/products", pvzHandler.AddProduct)
-
-		pr.With(middleware.RequireRole("employee")).Post("/pvz/{pvzId}/close_last_reception", pvzHandler.CloseLastReception)
-		pr.With(middleware.RequireRole("employee")).Post("/pvz/{pvzId}/delete_last_product", pvzHandler.DeleteLastProduct)
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/pvz/nearest", pvzHandler.FindNearestPVZ)
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/pvz/{pvzId}", pvzHandler.GetPVZ)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Patch("/pvz/{pvzId}", pvzHandler.UpdatePVZ)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/pvz/{pvzId}/decommission", pvzHandler.DecommissionPVZ)
+
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/receptions", pvzHandler.CreateReception)
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/products", pvzHandler.AddProduct)
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/products/batch", pvzHandler.AddProductsBatch)
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/products/by-barcode/{code}", pvzHandler.FindProductByBarcode)
+
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/pvz/{pvzId}/close_last_reception", pvzHandler.CloseLastReception)
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/pvz/{pvzId}/delete_last_product", pvzHandler.DeleteLastProduct)
+		pr.With(middleware.RequireRole("employee"), idempotent).Delete("/pvz/{pvzId}/products/{productId}", pvzHandler.DeleteProduct)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/receptions/{receptionId}/reopen", pvzHandler.ReopenReception)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/receptions/{receptionId}/cancel", pvzHandler.CancelReception)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Delete("/receptions/{receptionId}", pvzHandler.DeleteReception)
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/pvz/{pvzId}/capacity", pvzHandler.GetCapacity)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Put("/pvz/{pvzId}/capacity", pvzHandler.SetCapacity)
+
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/product-types", productTypeHandler.List)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/product-types", productTypeHandler.Create)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Patch("/product-types/{code}", productTypeHandler.Update)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Delete("/product-types/{code}", productTypeHandler.Delete)
+
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/cities", cityHandler.List)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/cities", cityHandler.Create)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Patch("/cities/{name}", cityHandler.Update)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Delete("/cities/{name}", cityHandler.Delete)
+
+		pr.With(middleware.RequireRole("moderator")).Get("/audit", auditHandler.List)
+
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/webhooks", webhookHandler.Create)
+		pr.With(middleware.RequireRole("moderator")).Get("/webhooks", webhookHandler.List)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Delete("/webhooks/{webhookId}", webhookHandler.Delete)
+		pr.With(middleware.RequireRole("moderator")).Get("/webhooks/{webhookId}/deliveries", webhookHandler.Deliveries)
 	})
 
 	httpAddr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
@@ -102,15 +155,54 @@ func New(cfg config.Config) (*App, error) {
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
+	outboxPublisher := outboxad.NewFanout(outboxad.NewLogPublisher(), webhookService)
+	outboxRelay := outboxUC.NewRelay(db.OutboxRepo(), outboxPublisher, clock, outboxUC.Config{
+		PollInterval: cfg.Outbox.PollInterval,
+		Lease:        cfg.Outbox.Lease,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxAttempts:  cfg.Outbox.MaxAttempts,
+		BaseBackoff:  cfg.Outbox.BaseBackoff,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+	})
+
+	webhookWorker := webhookUC.NewDispatcher(db, webhookad.NewHTTPSender(cfg.Webhook.Timeout), clock, webhookUC.DispatcherConfig{
+		PollInterval:           cfg.Webhook.PollInterval,
+		Lease:                  cfg.Webhook.Lease,
+		BatchSize:              cfg.Webhook.BatchSize,
+		MaxAttempts:            cfg.Webhook.MaxAttempts,
+		MaxConsecutiveFailures: cfg.Webhook.MaxConsecutiveFailures,
+		BaseBackoff:            cfg.Webhook.BaseBackoff,
+		MaxBackoff:             cfg.Webhook.MaxBackoff,
+	})
+
+	staleSweeper := recvUC.NewSweeper(receptionService, metricsCollector, logging.Logger, recvUC.SweeperConfig{
+		Interval:  cfg.Reception.StaleCheckInterval,
+		MaxAge:    cfg.Reception.StaleAfter,
+		Action:    cfg.Reception.StaleAction,
+		BatchSize: cfg.Reception.StaleBatchSize,
+	})
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+
 	return &App{
 		cfg:           cfg,
 		httpServer:    httpSrv,
 		metricsServer: metricsSrv,
 		db:            db,
+		outboxRelay:   outboxRelay,
+		webhookWorker: webhookWorker,
+		staleSweeper:  staleSweeper,
+		idempotency:   idempotencyService,
+		workersCtx:    workersCtx,
+		stopWorkers:   stopWorkers,
 	}, nil
 }
 
 func (a *App) Run(ctx context.Context) error {
+	go a.outboxRelay.Run(a.workersCtx)
+	go a.webhookWorker.Run(a.workersCtx)
+	go a.staleSweeper.Run(a.workersCtx)
+	go a.idempotency.Run(a.workersCtx, a.cfg.Idempotency.PurgeInterval)
 	go func() {
 		_ = a.metricsServer.ListenAndServe()
 	}()
@@ -122,6 +214,7 @@ func (a *App) Shutdown(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	a.stopWorkers()
 	err := a.httpServer.Shutdown(ctx)
 	_ = a.metricsServer.Shutdown(ctx)
 	if a.db != nil {
@@ -3,12 +3,19 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 type Config struct {
-	Server ServerConfig
-	DB     DBConfig
-	JWT    JWTConfig
+	Server      ServerConfig
+	DB          DBConfig
+	Migration   MigrationConfig
+	JWT         JWTConfig
+	Outbox      OutboxConfig
+	Watch       WatchConfig
+	Webhook     WebhookConfig
+	Reception   ReceptionConfig
+	Idempotency IdempotencyConfig
 }
 
 type ServerConfig struct {
@@ -25,10 +32,58 @@ type DBConfig struct {
 	Name     string
 }
 
+// MigrationConfig is read by pvz-migrator only.
+type MigrationConfig struct {
+	// LegacyTimezone is the zone the servers ran in before timestamps became
+	// TIMESTAMPTZ: the old TIMESTAMP columns hold wall-clock time in that zone.
+	LegacyTimezone string
+}
+
 type JWTConfig struct {
 	Secret string
 }
 
+type OutboxConfig struct {
+	PollInterval time.Duration
+	Lease        time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+}
+
+// WatchConfig controls how the gRPC watch streams follow the outbox.
+type WatchConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+type ReceptionConfig struct {
+	ReopenWindow time.Duration
+
+	StaleAfter         time.Duration
+	StaleCheckInterval time.Duration
+	StaleAction        string
+	StaleBatchSize     int
+}
+
+type IdempotencyConfig struct {
+	TTL           time.Duration
+	Lease         time.Duration
+	PurgeInterval time.Duration
+}
+
+type WebhookConfig struct {
+	PollInterval           time.Duration
+	Lease                  time.Duration
+	BatchSize              int
+	MaxAttempts            int
+	MaxConsecutiveFailures int
+	BaseBackoff            time.Duration
+	MaxBackoff             time.Duration
+	Timeout                time.Duration
+}
+
 func Load() Config {
 	cfg := Config{
 		Server: ServerConfig{
@@ -43,9 +98,47 @@ func Load() Config {
 			Password: "postgres",
 			Name:     "pvz",
 		},
+		Migration: MigrationConfig{
+			LegacyTimezone: "UTC",
+		},
 		JWT: JWTConfig{
 			Secret: "secret",
 		},
+		Outbox: OutboxConfig{
+			PollInterval: time.Second,
+			Lease:        time.Minute,
+			BatchSize:    100,
+			MaxAttempts:  10,
+			BaseBackoff:  time.Second,
+			MaxBackoff:   5 * time.Minute,
+		},
+		Watch: WatchConfig{
+			PollInterval: 200 * time.Millisecond,
+			BatchSize:    500,
+		},
+		Webhook: WebhookConfig{
+			PollInterval:           time.Second,
+			Lease:                  10 * time.Minute,
+			BatchSize:              50,
+			MaxAttempts:            8,
+			MaxConsecutiveFailures: 20,
+			BaseBackoff:            5 * time.Second,
+			MaxBackoff:             time.Hour,
+			Timeout:                10 * time.Second,
+		},
+		Reception: ReceptionConfig{
+			ReopenWindow: 24 * time.Hour,
+
+			StaleAfter:         24 * time.Hour,
+			StaleCheckInterval: 5 * time.Minute,
+			StaleAction:        "close",
+			StaleBatchSize:     100,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL:           24 * time.Hour,
+			Lease:         time.Minute,
+			PurgeInterval: time.Hour,
+		},
 	}
 	if portStr := os.Getenv("HTTP_PORT"); portStr != "" {
 		if p, err := strconv.Atoi(portStr); err == nil {
@@ -79,8 +172,87 @@ func Load() Config {
 	if name := os.Getenv("DB_NAME"); name != "" {
 		cfg.DB.Name = name
 	}
+	if tz := os.Getenv("TZ"); tz != "" {
+		cfg.Migration.LegacyTimezone = tz
+	}
+	if tz := os.Getenv("MIGRATION_LEGACY_TIMEZONE"); tz != "" {
+		cfg.Migration.LegacyTimezone = tz
+	}
 	if secret := os.Getenv("JWT_SECRET"); secret != "" {
 		cfg.JWT.Secret = secret
 	}
+	if v := os.Getenv("OUTBOX_POLL_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Outbox.PollInterval = d
+		}
+	}
+	if v := os.Getenv("OUTBOX_LEASE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Outbox.Lease = d
+		}
+	}
+	if v := os.Getenv("OUTBOX_BATCH_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Outbox.BatchSize = n
+		}
+	}
+	if v := os.Getenv("OUTBOX_MAX_ATTEMPTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Outbox.MaxAttempts = n
+		}
+	}
+	if v := os.Getenv("WATCH_POLL_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Watch.PollInterval = d
+		}
+	}
+	if v := os.Getenv("WEBHOOK_LEASE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Webhook.Lease = d
+		}
+	}
+	if v := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Webhook.MaxAttempts = n
+		}
+	}
+	if v := os.Getenv("WEBHOOK_MAX_CONSECUTIVE_FAILURES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Webhook.MaxConsecutiveFailures = n
+		}
+	}
+	if v := os.Getenv("WEBHOOK_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Webhook.Timeout = d
+		}
+	}
+	if v := os.Getenv("RECEPTION_REOPEN_WINDOW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Reception.ReopenWindow = d
+		}
+	}
+	if v := os.Getenv("RECEPTION_STALE_AFTER"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Reception.StaleAfter = d
+		}
+	}
+	if v := os.Getenv("RECEPTION_STALE_CHECK_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Reception.StaleCheckInterval = d
+		}
+	}
+	if v := os.Getenv("RECEPTION_STALE_ACTION"); v == "close" || v == "flag" {
+		cfg.Reception.StaleAction = v
+	}
+	if v := os.Getenv("IDEMPOTENCY_TTL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Idempotency.TTL = d
+		}
+	}
+	if v := os.Getenv("IDEMPOTENCY_LEASE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.Idempotency.Lease = d
+		}
+	}
 	return cfg
 }
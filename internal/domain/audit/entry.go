@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/domain/user"
+)
+
+// Action names mirror the domain event types: every audited mutation also
+// emits the matching event.
+type Action string
+
+const (
+	ActionPVZCreated      = Action(event.PVZCreated)
+	ActionReceptionOpened = Action(event.ReceptionOpened)
+	ActionReceptionClosed = Action(event.ReceptionClosed)
+	ActionProductAdded    = Action(event.ProductAdded)
+	ActionProductRemoved  = Action(event.ProductRemoved)
+
+	ActionReceptionReopened  = Action(event.ReceptionReopened)
+	ActionReceptionCancelled = Action(event.ReceptionCancelled)
+
+	// ActionReceptionFlaggedStale has no matching event: flagging does not
+	// change the reception itself.
+	ActionReceptionFlaggedStale Action = "reception_flagged_stale"
+	ActionPVZCapacityUpdated    Action = "pvz_capacity_updated"
+	ActionPVZUpdated            Action = "pvz_updated"
+	ActionPVZDecommissioned     Action = "pvz_decommissioned"
+	ActionReceptionDeleted      Action = "reception_deleted"
+)
+
+// The system actor stands for background jobs acting without a user.
+const (
+	SystemActorID   = "system"
+	SystemActorRole = "system"
+)
+
+type Entry struct {
+	ID          string
+	OccurredAt  time.Time
+	ActorID     string
+	ActorRole   string
+	Action      Action
+	PVZID       string
+	ReceptionID string
+	ProductID   string
+	Details     map[string]string
+}
+
+// Actor returns the authenticated user behind the request, if any.
+func Actor(ctx context.Context) (id, role string) {
+	if u, ok := user.FromContext(ctx); ok {
+		return u.ID, u.Role
+	}
+	return "", ""
+}
+
+// SystemContext marks mutations made within ctx as performed by the system.
+func SystemContext(ctx context.Context) context.Context {
+	return user.WithContext(ctx, &user.User{ID: SystemActorID, Role: SystemActorRole})
+}
+
+// ForEvent describes the mutation behind e on behalf of the user in ctx.
+func ForEvent(ctx context.Context, id string, e event.DomainEvent) Entry {
+	actorID, actorRole := Actor(ctx)
+	entry := Entry{
+		ID:         id,
+		OccurredAt: e.OccurredAt,
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Action:     Action(e.Type),
+		PVZID:      e.PVZID,
+		Details:    map[string]string{},
+	}
+	if e.City != "" {
+		entry.Details["city"] = e.City
+	}
+	if e.Reception != nil {
+		entry.ReceptionID = e.Reception.ID
+		entry.Details["receptionStatus"] = e.Reception.Status
+	}
+	if e.Product != nil {
+		entry.ProductID = e.Product.ID
+		entry.Details["productType"] = e.Product.Type
+		if e.Product.Barcode != "" {
+			entry.Details["barcode"] = e.Product.Barcode
+		}
+	}
+	return entry
+}
@@ -0,0 +1,42 @@
+package event
+
+import (
+	"time"
+
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/reception"
+)
+
+type Type string
+
+const (
+	PVZCreated      Type = "pvz_created"
+	ReceptionOpened Type = "reception_opened"
+	ReceptionClosed Type = "reception_closed"
+	ProductAdded    Type = "product_added"
+	ProductRemoved  Type = "product_removed"
+
+	ReceptionReopened  Type = "reception_reopened"
+	ReceptionCancelled Type = "reception_cancelled"
+)
+
+var AllTypes = []Type{PVZCreated, ReceptionOpened, ReceptionClosed, ProductAdded, ProductRemoved, ReceptionReopened, ReceptionCancelled}
+
+func (t Type) Valid() bool {
+	for _, known := range AllTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+type DomainEvent struct {
+	ID         string
+	Type       Type
+	PVZID      string
+	City       string
+	Reception  *reception.Reception
+	Product    *product.Product
+	OccurredAt time.Time
+}
@@ -0,0 +1,43 @@
+package idempotency
+
+import (
+	"errors"
+	"time"
+)
+
+const MaxKeyLength = 255
+
+var (
+	ErrInvalidKey        = errors.New("некорректный ключ идемпотентности")
+	ErrKeyReused         = errors.New("ключ идемпотентности уже использован с другим запросом")
+	ErrRequestInProgress = errors.New("запрос с этим ключом идемпотентности ещё выполняется")
+	ErrAnonymousCaller   = errors.New("ключ идемпотентности принимается только от пользователя с идентификатором")
+)
+
+// Record remembers the outcome of a request made with an idempotency key.
+// The transport decides what StatusCode, ContentType and Body mean.
+type Record struct {
+	Scope       string
+	Key         string
+	Fingerprint string
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	// LockedUntil bounds how long an unfinished request holds the key.
+	LockedUntil time.Time
+}
+
+func ValidKey(key string) bool {
+	if key == "" || len(key) > MaxKeyLength {
+		return false
+	}
+	for _, r := range key {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
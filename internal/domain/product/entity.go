@@ -1,12 +1,52 @@
 package product
 
-import "time"
+import (
+	"regexp"
+	"time"
+)
 
 type Product struct {
 	ID          string
 	AddedAt     time.Time
 	Type        string
 	ReceptionID string
+
+	Barcode              string
+	SKU                  string
+	WeightGrams          int
+	DeclaredValueKopecks int64
 }
 
-var AllowedTypes = []string{"electronics", "clothes", "shoes"}
+var barcodePattern = regexp.MustCompile(`^[0-9A-Za-z-]{4,64}$`)
+
+func ValidBarcode(code string) bool {
+	return barcodePattern.MatchString(code)
+}
+
+// ValidateDetails checks the optional scan fields; zero values mean "not provided".
+func (p *Product) ValidateDetails() error {
+	if p.Barcode != "" && !ValidBarcode(p.Barcode) {
+		return ErrInvalidBarcode
+	}
+	if p.WeightGrams < 0 {
+		return ErrInvalidWeight
+	}
+	if p.DeclaredValueKopecks < 0 {
+		return ErrInvalidValue
+	}
+	return nil
+}
+
+type Type struct {
+	Code      string
+	Names     map[string]string
+	Active    bool
+	CreatedAt time.Time
+}
+
+func (t *Type) Name(locale string) string {
+	if name, ok := t.Names[locale]; ok && name != "" {
+		return name
+	}
+	return t.Code
+}
@@ -3,5 +3,15 @@ package product
 import "errors"
 
 var (
-	ErrInvalidType = errors.New("недопустимый тип товара")
+	ErrInvalidType      = errors.New("недопустимый тип товара")
+	ErrAmbiguousType    = errors.New("название подходит к нескольким типам товара")
+	ErrInvalidTypeCode  = errors.New("некорректный код типа товара")
+	ErrTypeNotFound     = errors.New("тип товара не найден")
+	ErrTypeExists       = errors.New("тип товара уже существует")
+	ErrTypeInUse        = errors.New("тип товара используется в товарах")
+	ErrInvalidBarcode   = errors.New("некорректный штрихкод")
+	ErrInvalidWeight    = errors.New("некорректный вес товара")
+	ErrInvalidValue     = errors.New("некорректная объявленная стоимость")
+	ErrDuplicateBarcode = errors.New("товар с таким штрихкодом уже есть в приемке")
+	ErrNotFound         = errors.New("товар не найден")
 )
@@ -1,11 +1,107 @@
 package pvz
 
-import "time"
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MaxNameLength    = 200
+	MaxAddressLength = 500
+)
 
 type PVZ struct {
 	ID        string
 	CreatedAt time.Time
 	City      string
+	Timezone  string
+
+	// StaleReceptionAfter overrides the global age after which an open
+	// reception is considered forgotten; zero means the global setting.
+	StaleReceptionAfter time.Duration
+
+	Name             string
+	Address          string
+	WorkingHours     Schedule
+	DecommissionedAt *time.Time
+	// Coordinates are nil for PVZs registered without them.
+	Coordinates *Coordinates
+}
+
+func (p *PVZ) Active() bool {
+	return p.DecommissionedAt == nil
+}
+
+func ValidName(name string) bool {
+	name = strings.TrimSpace(name)
+	return name != "" && utf8.RuneCountInString(name) <= MaxNameLength
+}
+
+func ValidAddress(address string) bool {
+	address = strings.TrimSpace(address)
+	return address != "" && utf8.RuneCountInString(address) <= MaxAddressLength
 }
 
-var AllowedCities = []string{"Москва", "Санкт-Петербург", "Казань"}
+func (p *PVZ) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil || p.Timezone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+type City struct {
+	Name      string
+	Aliases   map[string]string
+	Timezone  string
+	Active    bool
+	CreatedAt time.Time
+}
+
+func (c *City) Matches(name string) bool {
+	if strings.EqualFold(c.Name, name) {
+		return true
+	}
+	for _, alias := range c.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Capacity limits how many products a single reception of the PVZ may hold,
+// in total and per product type code. Zero means no limit.
+type Capacity struct {
+	MaxProducts int
+	MaxPerType  map[string]int
+}
+
+func (c Capacity) Validate() error {
+	if c.MaxProducts < 0 {
+		return ErrInvalidCapacity
+	}
+	for code, n := range c.MaxPerType {
+		if code == "" || n <= 0 {
+			return ErrInvalidCapacity
+		}
+	}
+	return nil
+}
+
+func (c Capacity) Limited() bool {
+	return c.MaxProducts > 0 || len(c.MaxPerType) > 0
+}
+
+// Admit checks whether one more product of productType fits into a reception
+// already holding total products, ofType of which have that type.
+func (c Capacity) Admit(total, ofType int, productType string) error {
+	if c.MaxProducts > 0 && total >= c.MaxProducts {
+		return ErrCapacityExceeded
+	}
+	if limit, ok := c.MaxPerType[productType]; ok && ofType >= limit {
+		return ErrCapacityExceeded
+	}
+	return nil
+}
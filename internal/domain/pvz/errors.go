@@ -3,5 +3,22 @@ package pvz
 import "errors"
 
 var (
-	ErrCityNotAllowed = errors.New("город не поддерживается")
+	ErrCityNotAllowed   = errors.New("город не поддерживается")
+	ErrCityAmbiguous    = errors.New("название подходит к нескольким городам")
+	ErrNotFound         = errors.New("ПВЗ не найден")
+	ErrInvalidCityName  = errors.New("некорректное название города")
+	ErrInvalidTimezone  = errors.New("некорректный часовой пояс")
+	ErrCityNotFound     = errors.New("город не найден")
+	ErrCityExists       = errors.New("город уже существует")
+	ErrCityInUse        = errors.New("в городе есть ПВЗ")
+	ErrInvalidStaleAge  = errors.New("некорректный срок автозакрытия приёмки")
+	ErrInvalidCapacity  = errors.New("некорректные ограничения вместимости")
+	ErrCapacityExceeded = errors.New("вместимость ПВЗ исчерпана")
+	ErrInvalidName      = errors.New("некорректное название ПВЗ")
+	ErrInvalidAddress   = errors.New("некорректный адрес ПВЗ")
+	ErrInvalidSchedule  = errors.New("некорректный график работы")
+	ErrDecommissioned   = errors.New("ПВЗ выведен из эксплуатации")
+	ErrHasOpenReception = errors.New("в ПВЗ есть незакрытая приёмка")
+
+	ErrInvalidCoordinates = errors.New("некорректные координаты")
 )
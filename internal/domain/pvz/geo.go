@@ -0,0 +1,17 @@
+package pvz
+
+import "math"
+
+// Coordinates are WGS 84 degrees.
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+func (c Coordinates) Validate() error {
+	if math.IsNaN(c.Latitude) || math.IsNaN(c.Longitude) ||
+		c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 {
+		return ErrInvalidCoordinates
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package pvz
+
+import "time"
+
+// Weekdays are the keys of a Schedule, Monday first.
+var Weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+const clockLayout = "15:04"
+
+// OpeningHours is a single working interval in the PVZ's local time, "HH:MM".
+type OpeningHours struct {
+	Open  string
+	Close string
+}
+
+// Schedule maps a weekday key to its working hours; a missing day is a day off.
+type Schedule map[string]OpeningHours
+
+func (s Schedule) Validate() error {
+	for day, h := range s {
+		if !isWeekday(day) {
+			return ErrInvalidSchedule
+		}
+		open, err := time.Parse(clockLayout, h.Open)
+		if err != nil {
+			return ErrInvalidSchedule
+		}
+		closeAt, err := time.Parse(clockLayout, h.Close)
+		if err != nil || !open.Before(closeAt) {
+			return ErrInvalidSchedule
+		}
+	}
+	return nil
+}
+
+func isWeekday(day string) bool {
+	for _, d := range Weekdays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
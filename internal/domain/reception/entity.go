@@ -5,11 +5,30 @@ import "time"
 const (
 	StatusInProgress = "in_progress"
 	StatusClosed     = "closed"
+	StatusCancelled  = "cancelled"
+	StatusReopened   = "reopened"
 )
 
+func ValidStatus(s string) bool {
+	switch s {
+	case StatusInProgress, StatusClosed, StatusCancelled, StatusReopened:
+		return true
+	}
+	return false
+}
+
 type Reception struct {
 	ID        string
 	StartedAt time.Time
 	PVZID     string
 	Status    string
+
+	ClosedAt     *time.Time
+	OpenedBy     string
+	ClosedBy     string
+	ProductCount int
+
+	// ReopenedAt is when the reception was last reopened; a stale reopened
+	// reception is aged from it.
+	ReopenedAt *time.Time
 }
@@ -7,4 +7,9 @@ var (
 	ErrNoOpenReception      = errors.New("нет открытой приёмки")
 	ErrReceptionClosed      = errors.New("приёмка уже закрыта")
 	ErrNoProducts           = errors.New("в приёмке нет товаров")
+	ErrNotFound             = errors.New("приёмка не найдена")
+	ErrInvalidTransition    = errors.New("недопустимая смена статуса приёмки")
+	ErrReopenWindowExpired  = errors.New("срок для повторного открытия приёмки истёк")
+	ErrReceptionNotEmpty    = errors.New("в приёмке есть товары")
+	ErrNotCancelled         = errors.New("удалить можно только отменённую приёмку")
 )
@@ -1,8 +1,87 @@
 package reception
 
+import "time"
+
+// transitions lists the statuses reachable from each status. A reception is
+// opened as in_progress; cancelled is terminal.
+var transitions = map[string][]string{
+	StatusInProgress: {StatusClosed, StatusCancelled},
+	StatusReopened:   {StatusClosed, StatusCancelled},
+	StatusClosed:     {StatusReopened, StatusCancelled},
+}
+
+// IsOpen reports whether products can still be added to or removed from a
+// reception in the given status.
+func IsOpen(status string) bool {
+	return status == StatusInProgress || status == StatusReopened
+}
+
 func CanOpenNew(last *Reception) bool {
 	if last == nil {
 		return true
 	}
-	return last.Status != StatusInProgress
+	return !IsOpen(last.Status)
+}
+
+func CanTransition(from, to string) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Close moves an open reception to closed.
+func (r *Reception) Close(by string, at time.Time, productCount int) error {
+	if !CanTransition(r.Status, StatusClosed) {
+		return ErrReceptionClosed
+	}
+	r.Status = StatusClosed
+	r.ClosedAt = &at
+	r.ClosedBy = by
+	r.ProductCount = productCount
+	return nil
+}
+
+// Reopen moves a closed reception back to an open state, provided it was
+// closed no longer than window ago.
+func (r *Reception) Reopen(now time.Time, window time.Duration) error {
+	if !CanTransition(r.Status, StatusReopened) {
+		return ErrInvalidTransition
+	}
+	if r.ClosedAt == nil || now.Sub(*r.ClosedAt) > window {
+		return ErrReopenWindowExpired
+	}
+	r.Status = StatusReopened
+	r.ReopenedAt = &now
+	r.ClosedAt = nil
+	r.ClosedBy = ""
+	r.ProductCount = 0
+	return nil
+}
+
+// CanDelete reports whether the reception may be hidden from listings: only
+// a cancelled one, so that nothing received disappears without a trace.
+func (r *Reception) CanDelete() error {
+	if r.Status != StatusCancelled {
+		return ErrNotCancelled
+	}
+	return nil
+}
+
+// Cancel discards a reception. Open receptions can always be cancelled,
+// closed ones only while they are empty.
+func (r *Reception) Cancel(by string, at time.Time, productCount int) error {
+	if !CanTransition(r.Status, StatusCancelled) {
+		return ErrInvalidTransition
+	}
+	if r.Status == StatusClosed && productCount > 0 {
+		return ErrReceptionNotEmpty
+	}
+	r.Status = StatusCancelled
+	r.ClosedAt = &at
+	r.ClosedBy = by
+	r.ProductCount = productCount
+	return nil
 }
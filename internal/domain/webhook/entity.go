@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"strings"
+	"time"
+
+	"pvz-service/internal/domain/event"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+type Subscription struct {
+	ID                  string
+	URL                 string
+	EventTypes          []event.Type
+	PVZID               string
+	City                string
+	Secret              string
+	Active              bool
+	ConsecutiveFailures int
+	CreatedAt           time.Time
+	DisabledAt          *time.Time
+}
+
+func (s *Subscription) Matches(e event.DomainEvent) bool {
+	if !s.Active {
+		return false
+	}
+	if s.PVZID != "" && s.PVZID != e.PVZID {
+		return false
+	}
+	if s.City != "" && !strings.EqualFold(s.City, e.City) {
+		return false
+	}
+	for _, t := range s.EventTypes {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
+
+type Delivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      event.Type
+	Payload        []byte
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+}
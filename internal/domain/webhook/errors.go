@@ -0,0 +1,10 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("подписка не найдена")
+	ErrInvalidURL       = errors.New("некорректный URL подписки")
+	ErrInvalidEventType = errors.New("неизвестный тип события")
+	ErrEmptySecret      = errors.New("секрет подписки не задан")
+)
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"pvz-service/internal/transport/grpc/pb"
+	"pvz-service/internal/usecase/auth"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AuthServer struct {
+	pb.UnimplementedAuthServiceServer
+	authService *auth.Service
+}
+
+func NewAuthServer(authService *auth.Service) *AuthServer {
+	return &AuthServer{authService: authService}
+}
+
+func (s *AuthServer) DummyLogin(ctx context.Context, req *pb.DummyLoginRequest) (*pb.TokenResponse, error) {
+	token, err := s.authService.DummyLogin(ctx, req.GetRole())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.TokenResponse{Token: token.Token}, nil
+}
+
+func (s *AuthServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.User, error) {
+	if req.GetEmail() == "" || req.GetPassword() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email and password are required")
+	}
+	result, err := s.authService.Register(ctx, req.GetEmail(), req.GetPassword(), req.GetRole())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.User{
+		Id:    result.UserID,
+		Email: strings.ToLower(req.GetEmail()),
+		Role:  strings.ToLower(req.GetRole()),
+	}, nil
+}
+
+func (s *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.TokenResponse, error) {
+	token, err := s.authService.Login(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.TokenResponse{Token: token.Token}, nil
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/domain/user"
+	"pvz-service/internal/usecase/auth"
+	pvzuc "pvz-service/internal/usecase/pvz"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, pvz.ErrCityNotAllowed),
+		errors.Is(err, pvz.ErrCityAmbiguous),
+		errors.Is(err, pvz.ErrInvalidTimezone),
+		errors.Is(err, pvz.ErrInvalidStaleAge),
+		errors.Is(err, pvz.ErrInvalidName),
+		errors.Is(err, pvz.ErrInvalidAddress),
+		errors.Is(err, pvz.ErrInvalidSchedule),
+		errors.Is(err, pvz.ErrInvalidCoordinates),
+		errors.Is(err, pvzuc.ErrInvalidRadius),
+		errors.Is(err, pvzuc.ErrInvalidFilter),
+		errors.Is(err, product.ErrInvalidType),
+		errors.Is(err, product.ErrAmbiguousType),
+		errors.Is(err, product.ErrInvalidBarcode),
+		errors.Is(err, product.ErrInvalidWeight),
+		errors.Is(err, product.ErrInvalidValue),
+		errors.Is(err, auth.ErrInvalidUserType),
+		errors.Is(err, pvzuc.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, pvz.ErrNotFound),
+		errors.Is(err, product.ErrNotFound),
+		errors.Is(err, reception.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, user.ErrEmailAlreadyExists),
+		errors.Is(err, product.ErrDuplicateBarcode):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, reception.ErrReceptionAlreadyOpen),
+		errors.Is(err, reception.ErrNoOpenReception),
+		errors.Is(err, reception.ErrReceptionClosed),
+		errors.Is(err, reception.ErrNoProducts),
+		errors.Is(err, reception.ErrInvalidTransition),
+		errors.Is(err, reception.ErrReopenWindowExpired),
+		errors.Is(err, reception.ErrReceptionNotEmpty),
+		errors.Is(err, pvz.ErrDecommissioned),
+		errors.Is(err, pvz.ErrHasOpenReception):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, pvz.ErrCapacityExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, user.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal error")
+	}
+}
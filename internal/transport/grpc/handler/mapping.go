@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/transport/grpc/pb"
+	"pvz-service/internal/usecase/catalog"
+	"pvz-service/internal/usecase/ports"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func receptionStatusToPB(s string) pb.ReceptionStatus {
+	switch s {
+	case reception.StatusClosed:
+		return pb.ReceptionStatus_RECEPTION_STATUS_CLOSED
+	case reception.StatusCancelled:
+		return pb.ReceptionStatus_RECEPTION_STATUS_CANCELLED
+	case reception.StatusReopened:
+		return pb.ReceptionStatus_RECEPTION_STATUS_REOPENED
+	default:
+		return pb.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS
+	}
+}
+
+func receptionStatusFromPB(s pb.ReceptionStatus) string {
+	switch s {
+	case pb.ReceptionStatus_RECEPTION_STATUS_CLOSED:
+		return reception.StatusClosed
+	case pb.ReceptionStatus_RECEPTION_STATUS_CANCELLED:
+		return reception.StatusCancelled
+	case pb.ReceptionStatus_RECEPTION_STATUS_REOPENED:
+		return reception.StatusReopened
+	default:
+		return reception.StatusInProgress
+	}
+}
+
+func dateFilterFromPB(f pb.DateFilter) string {
+	switch f {
+	case pb.DateFilter_DATE_FILTER_PRODUCT:
+		return string(ports.DateFieldProduct)
+	case pb.DateFilter_DATE_FILTER_PVZ:
+		return string(ports.DateFieldPVZ)
+	default:
+		return string(ports.DateFieldReception)
+	}
+}
+
+func receptionToPB(r *reception.Reception) *pb.Reception {
+	out := &pb.Reception{
+		Id:           r.ID,
+		DateTime:     timestamppb.New(r.StartedAt),
+		PvzId:        r.PVZID,
+		Status:       receptionStatusToPB(r.Status),
+		OpenedBy:     r.OpenedBy,
+		ClosedBy:     r.ClosedBy,
+		ProductCount: int32(r.ProductCount),
+	}
+	if r.ClosedAt != nil {
+		out.ClosedAt = timestamppb.New(*r.ClosedAt)
+	}
+	return out
+}
+
+func (s *PVZServer) productToPB(ctx context.Context, p *product.Product) *pb.Product {
+	return &pb.Product{
+		Id:          p.ID,
+		DateTime:    timestamppb.New(p.AddedAt),
+		Type:        s.catalog.DisplayName(ctx, p.Type, catalog.DefaultLocale),
+		ReceptionId: p.ReceptionID,
+
+		Barcode:              p.Barcode,
+		Sku:                  p.SKU,
+		WeightGrams:          int32(p.WeightGrams),
+		DeclaredValueKopecks: p.DeclaredValueKopecks,
+	}
+}
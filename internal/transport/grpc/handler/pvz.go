@@ -2,35 +2,272 @@ package handler
 
 import (
 	"context"
+	"time"
 
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/pvz"
 	"pvz-service/internal/transport/grpc/pb"
+	"pvz-service/internal/usecase/catalog"
+	"pvz-service/internal/usecase/ports"
 	pvzuc "pvz-service/internal/usecase/pvz"
+	receptionuc "pvz-service/internal/usecase/reception"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// the same page bounds as GET /pvz
+const (
+	defaultListLimit = 10
+	maxListLimit     = 30
+)
+
 type PVZServer struct {
 	pb.UnimplementedPVZServiceServer
-	pvzService *pvzuc.Service
+	pvzService       *pvzuc.Service
+	receptionService *receptionuc.Service
+	catalog          *catalog.Service
+	events           ports.EventSubscriber
 }
 
-func NewPVZServer(pvzService *pvzuc.Service) *PVZServer {
-	return &PVZServer{pvzService: pvzService}
+func NewPVZServer(pvzService *pvzuc.Service, receptionService *receptionuc.Service, catalogService *catalog.Service, events ports.EventSubscriber) *PVZServer {
+	return &PVZServer{pvzService: pvzService, receptionService: receptionService, catalog: catalogService, events: events}
 }
 
-func (s *PVZServer) GetPVZList(ctx context.Context, _ *pb.GetPVZListRequest) (*pb.GetPVZListResponse, error) {
-	list, err := s.pvzService.List(ctx, nil, nil, 100, 0)
+func (s *PVZServer) GetPVZList(ctx context.Context, req *pb.GetPVZListRequest) (*pb.GetPVZListResponse, error) {
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must not exceed %d", maxListLimit)
+	}
+	offset := 0
+	if page := int(req.GetPage()); page > 1 && req.GetCursor() == "" {
+		offset = (page - 1) * limit
+	}
+
+	params := pvzuc.ListParams{
+		Limit:    limit,
+		Offset:   offset,
+		Cursor:   req.GetCursor(),
+		OpenedBy: req.GetOpenedBy(),
+		ClosedBy: req.GetClosedBy(),
+
+		IncludeDecommissioned: req.GetIncludeDecommissioned(),
+
+		City:             req.GetCity(),
+		HasOpenReception: req.HasOpenReception,
+	}
+	for _, st := range req.GetReceptionStatuses() {
+		params.ReceptionStatuses = append(params.ReceptionStatuses, receptionStatusFromPB(st))
+	}
+	if req.GetProductType() != "" {
+		internalType, err := s.catalog.Resolve(ctx, req.GetProductType())
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		params.ProductType = internalType
+	}
+	if req.MinProducts != nil {
+		n := int(req.GetMinProducts())
+		params.MinProducts = &n
+	}
+	if req.MaxProducts != nil {
+		n := int(req.GetMaxProducts())
+		params.MaxProducts = &n
+	}
+	if req.From != nil {
+		t := req.GetFrom().AsTime()
+		params.From = &t
+	}
+	if req.To != nil {
+		t := req.GetTo().AsTime()
+		params.To = &t
+	}
+	params.DateField = dateFilterFromPB(req.GetDateFilter())
+	params.IncludeEmpty = req.GetIncludeEmpty()
+	if req.ClosedFrom != nil {
+		t := req.GetClosedFrom().AsTime()
+		params.ClosedFrom = &t
+	}
+	if req.ClosedTo != nil {
+		t := req.GetClosedTo().AsTime()
+		params.ClosedTo = &t
+	}
+	list, err := s.pvzService.List(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
-	resp := &pb.GetPVZListResponse{}
-	for _, p := range list {
-		resp.Pvzs = append(resp.Pvzs, &pb.PVZ{
-			Id:               p.ID,
-			RegistrationDate: timestamppb.New(p.CreatedAt),
-			City:             p.City,
-		})
+	resp := &pb.GetPVZListResponse{NextCursor: list.NextCursor}
+	for _, p := range list.Items {
+		resp.Pvzs = append(resp.Pvzs, pvzToPB(p))
+	}
+	return resp, nil
+}
+
+func (s *PVZServer) CreatePVZ(ctx context.Context, req *pb.CreatePVZRequest) (*pb.PVZ, error) {
+	if req.GetCity() == "" {
+		return nil, status.Error(codes.InvalidArgument, "city is required")
+	}
+	params := pvzuc.CreateParams{
+		City:                req.GetCity(),
+		Timezone:            req.GetTimezone(),
+		StaleReceptionAfter: time.Duration(req.GetStaleReceptionAfterMinutes()) * time.Minute,
+
+		Name:    req.GetName(),
+		Address: req.GetAddress(),
+	}
+	if len(req.GetWorkingHours()) > 0 {
+		params.WorkingHours = make(pvz.Schedule, len(req.GetWorkingHours()))
+		for day, h := range req.GetWorkingHours() {
+			params.WorkingHours[day] = pvz.OpeningHours{Open: h.GetOpen(), Close: h.GetClose()}
+		}
+	}
+	if c := req.GetCoordinates(); c != nil {
+		params.Coordinates = &pvz.Coordinates{Latitude: c.GetLatitude(), Longitude: c.GetLongitude()}
+	}
+	result, err := s.pvzService.Create(ctx, params)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return pvzToPB(*result), nil
+}
+
+func pvzToPB(p pvzuc.PVZInfo) *pb.PVZ {
+	out := &pb.PVZ{
+		Id:               p.ID,
+		RegistrationDate: timestamppb.New(p.CreatedAt),
+		City:             p.City,
+		Timezone:         p.Timezone,
+
+		StaleReceptionAfterMinutes: int32(p.StaleReceptionAfter / time.Minute),
+	}
+	if p.DecommissionedAt != nil {
+		out.DecommissionedAt = timestamppb.New(*p.DecommissionedAt)
+	}
+	out.Name = p.Name
+	out.Address = p.Address
+	if len(p.WorkingHours) > 0 {
+		out.WorkingHours = make(map[string]*pb.OpeningHours, len(p.WorkingHours))
+		for day, h := range p.WorkingHours {
+			out.WorkingHours[day] = &pb.OpeningHours{Open: h.Open, Close: h.Close}
+		}
+	}
+	if p.Coordinates != nil {
+		out.Coordinates = &pb.Coordinates{Latitude: p.Coordinates.Latitude, Longitude: p.Coordinates.Longitude}
+	}
+	return out
+}
+
+func (s *PVZServer) FindNearestPVZ(ctx context.Context, req *pb.FindNearestPVZRequest) (*pb.FindNearestPVZResponse, error) {
+	if req.GetFrom() == nil {
+		return nil, status.Error(codes.InvalidArgument, "from is required")
+	}
+	found, err := s.pvzService.Nearest(ctx, pvzuc.NearestParams{
+		From:     pvz.Coordinates{Latitude: req.GetFrom().GetLatitude(), Longitude: req.GetFrom().GetLongitude()},
+		RadiusKm: req.GetRadiusKm(),
+		Limit:    int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.FindNearestPVZResponse{}
+	for _, f := range found {
+		resp.Pvzs = append(resp.Pvzs, &pb.NearbyPVZ{Pvz: pvzToPB(f.PVZInfo), DistanceKm: f.DistanceKm})
 	}
 	return resp, nil
 }
+
+func (s *PVZServer) OpenReception(ctx context.Context, req *pb.OpenReceptionRequest) (*pb.Reception, error) {
+	if req.GetPvzId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "pvz_id is required")
+	}
+	rec, err := s.receptionService.Open(ctx, req.GetPvzId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return receptionToPB(rec), nil
+}
+
+func (s *PVZServer) AddProduct(ctx context.Context, req *pb.AddProductRequest) (*pb.Product, error) {
+	if req.GetPvzId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "pvz_id is required")
+	}
+	internalType, err := s.catalog.Resolve(ctx, req.GetType())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	var pr *product.Product
+	if req.GetBarcode() != "" {
+		pr, err = s.receptionService.AddScannedProduct(ctx, req.GetPvzId(), internalType, receptionuc.ProductDetails{
+			Barcode:              req.GetBarcode(),
+			SKU:                  req.GetSku(),
+			WeightGrams:          int(req.GetWeightGrams()),
+			DeclaredValueKopecks: req.GetDeclaredValueKopecks(),
+		})
+	} else {
+		pr, err = s.receptionService.AddProduct(ctx, req.GetPvzId(), internalType)
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return s.productToPB(ctx, pr), nil
+}
+
+func (s *PVZServer) DeleteLastProduct(ctx context.Context, req *pb.DeleteLastProductRequest) (*pb.Product, error) {
+	if req.GetPvzId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "pvz_id is required")
+	}
+	pr, err := s.receptionService.RemoveProduct(ctx, req.GetPvzId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return s.productToPB(ctx, pr), nil
+}
+
+func (s *PVZServer) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.Product, error) {
+	if req.GetPvzId() == "" || req.GetProductId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "pvz_id and product_id are required")
+	}
+	pr, err := s.receptionService.RemoveProductByID(ctx, req.GetPvzId(), req.GetProductId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return s.productToPB(ctx, pr), nil
+}
+
+func (s *PVZServer) CloseLastReception(ctx context.Context, req *pb.CloseLastReceptionRequest) (*pb.Reception, error) {
+	if req.GetPvzId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "pvz_id is required")
+	}
+	rec, err := s.receptionService.Close(ctx, req.GetPvzId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return receptionToPB(rec), nil
+}
+
+func (s *PVZServer) ReopenReception(ctx context.Context, req *pb.ReopenReceptionRequest) (*pb.Reception, error) {
+	if req.GetReceptionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reception_id is required")
+	}
+	rec, err := s.receptionService.Reopen(ctx, req.GetReceptionId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return receptionToPB(rec), nil
+}
+
+func (s *PVZServer) CancelReception(ctx context.Context, req *pb.CancelReceptionRequest) (*pb.Reception, error) {
+	if req.GetReceptionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reception_id is required")
+	}
+	rec, err := s.receptionService.Cancel(ctx, req.GetReceptionId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return receptionToPB(rec), nil
+}
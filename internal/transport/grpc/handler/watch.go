@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/transport/grpc/pb"
+	"pvz-service/internal/usecase/ports"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *PVZServer) WatchPVZ(req *pb.WatchPVZRequest, stream pb.PVZService_WatchPVZServer) error {
+	var filter func(event.DomainEvent) bool
+	switch target := req.GetTarget().(type) {
+	case *pb.WatchPVZRequest_PvzId:
+		if target.PvzId == "" {
+			return status.Error(codes.InvalidArgument, "pvz_id is required")
+		}
+		filter = func(e event.DomainEvent) bool { return e.PVZID == target.PvzId }
+	case *pb.WatchPVZRequest_City:
+		if target.City == "" {
+			return status.Error(codes.InvalidArgument, "city is required")
+		}
+		city, err := s.pvzService.CanonicalCity(stream.Context(), target.City)
+		if err != nil {
+			return toStatus(err)
+		}
+		filter = func(e event.DomainEvent) bool { return strings.EqualFold(e.City, city) }
+	default:
+		return status.Error(codes.InvalidArgument, "pvz_id or city is required")
+	}
+
+	ctx := stream.Context()
+	events, err := s.events.Subscribe(ctx, req.GetAfterSequence(), filter)
+	if err != nil {
+		if errors.Is(err, ports.ErrSequenceExpired) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		return toStatus(err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case se, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return status.Error(codes.Aborted, "subscriber is too slow, resume from the last received sequence")
+			}
+			if err := stream.Send(s.eventToPB(stream.Context(), se)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func eventTypeToPB(t event.Type) pb.PVZEventType {
+	switch t {
+	case event.ReceptionOpened:
+		return pb.PVZEventType_PVZ_EVENT_TYPE_RECEPTION_OPENED
+	case event.ReceptionClosed:
+		return pb.PVZEventType_PVZ_EVENT_TYPE_RECEPTION_CLOSED
+	case event.ProductAdded:
+		return pb.PVZEventType_PVZ_EVENT_TYPE_PRODUCT_ADDED
+	case event.ProductRemoved:
+		return pb.PVZEventType_PVZ_EVENT_TYPE_PRODUCT_REMOVED
+	case event.ReceptionReopened:
+		return pb.PVZEventType_PVZ_EVENT_TYPE_RECEPTION_REOPENED
+	case event.ReceptionCancelled:
+		return pb.PVZEventType_PVZ_EVENT_TYPE_RECEPTION_CANCELLED
+	default:
+		return pb.PVZEventType_PVZ_EVENT_TYPE_UNSPECIFIED
+	}
+}
+
+func (s *PVZServer) eventToPB(ctx context.Context, se ports.SequencedEvent) *pb.PVZEvent {
+	e := se.Event
+	out := &pb.PVZEvent{
+		Sequence:   se.Sequence,
+		Type:       eventTypeToPB(e.Type),
+		PvzId:      e.PVZID,
+		City:       e.City,
+		OccurredAt: timestamppb.New(e.OccurredAt),
+	}
+	if e.Reception != nil {
+		out.Reception = receptionToPB(e.Reception)
+	}
+	if e.Product != nil {
+		out.Product = s.productToPB(ctx, e.Product)
+	}
+	return out
+}
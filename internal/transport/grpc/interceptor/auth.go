@@ -0,0 +1,89 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"pvz-service/internal/domain/user"
+	"pvz-service/internal/usecase/ports"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type AuthInterceptor struct {
+	tokenManager  ports.TokenManager
+	publicMethods map[string]bool
+	methodRoles   map[string]map[string]bool
+}
+
+func NewAuthInterceptor(tokenManager ports.TokenManager, publicMethods []string, methodRoles map[string][]string) *AuthInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, m := range publicMethods {
+		public[m] = true
+	}
+	roles := make(map[string]map[string]bool, len(methodRoles))
+	for method, allowed := range methodRoles {
+		roleSet := make(map[string]bool, len(allowed))
+		for _, role := range allowed {
+			roleSet[role] = true
+		}
+		roles[method] = roleSet
+	}
+	return &AuthInterceptor{tokenManager: tokenManager, publicMethods: public, methodRoles: roles}
+}
+
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
+	if i.publicMethods[method] {
+		return ctx, nil
+	}
+	roleSet, ok := i.methodRoles[method]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "Forbidden")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	usr, err := i.tokenManager.ParseToken(strings.TrimPrefix(values[0], "Bearer "))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	if !roleSet[usr.Role] {
+		return nil, status.Error(codes.PermissionDenied, "Forbidden")
+	}
+	return user.WithContext(ctx, usr), nil
+}
+
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,145 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"pvz-service/internal/adapter/auth/jwt"
+	"pvz-service/internal/domain/user"
+	"pvz-service/internal/transport/grpc/pb"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type authCase struct {
+	name  string
+	token string
+	role  string
+}
+
+func authCases(t *testing.T) []authCase {
+	t.Helper()
+	tm := jwt.NewTokenManagerJWT("test-secret")
+	cases := []authCase{
+		{name: "no token"},
+		{name: "malformed token", token: "not-a-jwt"},
+	}
+	for _, role := range []string{user.RoleClient, user.RoleModerator} {
+		token, err := tm.GenerateToken(&user.User{ID: "user-" + role, Role: role})
+		require.NoError(t, err)
+		cases = append(cases, authCase{name: role, token: token, role: role})
+	}
+	foreign, err := jwt.NewTokenManagerJWT("other-secret").GenerateToken(&user.User{ID: "intruder", Role: user.RoleModerator})
+	require.NoError(t, err)
+	return append(cases, authCase{name: "foreign signature", token: foreign})
+}
+
+func incoming(token string) context.Context {
+	if token == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+// wantCode is what the interceptor must answer for method called with c.
+func wantCode(method string, c authCase) codes.Code {
+	for _, m := range PublicMethods {
+		if m == method {
+			return codes.OK
+		}
+	}
+	allowed, ok := MethodRoles[method]
+	if !ok {
+		return codes.PermissionDenied
+	}
+	if c.role == "" {
+		return codes.Unauthenticated
+	}
+	for _, role := range allowed {
+		if role == c.role {
+			return codes.OK
+		}
+	}
+	return codes.PermissionDenied
+}
+
+func TestEveryMethodHasAccessRules(t *testing.T) {
+	rules := map[string]bool{}
+	for _, m := range PublicMethods {
+		rules[m] = true
+	}
+	for m := range MethodRoles {
+		rules[m] = true
+	}
+	for _, desc := range []grpc.ServiceDesc{pb.PVZService_ServiceDesc, pb.AuthService_ServiceDesc} {
+		for _, m := range desc.Methods {
+			require.True(t, rules["/"+desc.ServiceName+"/"+m.MethodName], m.MethodName)
+		}
+		for _, s := range desc.Streams {
+			require.True(t, rules["/"+desc.ServiceName+"/"+s.StreamName], s.StreamName)
+		}
+	}
+}
+
+func TestAuthInterceptorUnary(t *testing.T) {
+	i := NewAuthInterceptor(jwt.NewTokenManagerJWT("test-secret"), PublicMethods, MethodRoles)
+	methods := append([]string{"/pvz.PVZService/Unknown"}, PublicMethods...)
+	for m := range MethodRoles {
+		methods = append(methods, m)
+	}
+
+	for _, method := range methods {
+		for _, c := range authCases(t) {
+			t.Run(method+"/"+c.name, func(t *testing.T) {
+				var seen *user.User
+				_, err := i.Unary()(incoming(c.token), nil, &grpc.UnaryServerInfo{FullMethod: method},
+					func(ctx context.Context, _ interface{}) (interface{}, error) {
+						seen, _ = user.FromContext(ctx)
+						return "ok", nil
+					})
+
+				want := wantCode(method, c)
+				require.Equal(t, want, status.Code(err))
+				if want != codes.OK || c.role == "" || MethodRoles[method] == nil {
+					return
+				}
+				require.NotNil(t, seen)
+				require.Equal(t, c.role, seen.Role)
+			})
+		}
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func TestAuthInterceptorStream(t *testing.T) {
+	i := NewAuthInterceptor(jwt.NewTokenManagerJWT("test-secret"), PublicMethods, MethodRoles)
+	method := pb.PVZService_WatchPVZ_FullMethodName
+
+	for _, c := range authCases(t) {
+		t.Run(c.name, func(t *testing.T) {
+			called := false
+			err := i.Stream()(nil, &fakeStream{ctx: incoming(c.token)}, &grpc.StreamServerInfo{FullMethod: method},
+				func(_ interface{}, ss grpc.ServerStream) error {
+					called = true
+					u, ok := user.FromContext(ss.Context())
+					require.True(t, ok, "the handler must see the caller")
+					require.Equal(t, c.role, u.Role)
+					return nil
+				})
+
+			want := wantCode(method, c)
+			require.Equal(t, want, status.Code(err))
+			require.Equal(t, want == codes.OK, called)
+		})
+	}
+}
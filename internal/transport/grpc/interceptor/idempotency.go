@@ -0,0 +1,127 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	domain "pvz-service/internal/domain/idempotency"
+	"pvz-service/internal/transport/grpc/pb"
+	"pvz-service/internal/usecase/idempotency"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const IdempotencyKeyMetadata = "idempotency-key"
+
+var IdempotentMethods = []string{
+	pb.PVZService_CreatePVZ_FullMethodName,
+	pb.PVZService_OpenReception_FullMethodName,
+	pb.PVZService_AddProduct_FullMethodName,
+	pb.PVZService_DeleteLastProduct_FullMethodName,
+	pb.PVZService_DeleteProduct_FullMethodName,
+	pb.PVZService_CloseLastReception_FullMethodName,
+	pb.PVZService_ReopenReception_FullMethodName,
+	pb.PVZService_CancelReception_FullMethodName,
+}
+
+// IdempotencyInterceptor is the gRPC counterpart of the HTTP Idempotency-Key
+// middleware. A successful response is stored as the serialized message with
+// its full name as content type; a failed call is stored as its status code
+// and message. Internal and Unavailable errors are not stored.
+type IdempotencyInterceptor struct {
+	svc     *idempotency.Service
+	methods map[string]bool
+}
+
+func NewIdempotencyInterceptor(svc *idempotency.Service, methods []string) *IdempotencyInterceptor {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[m] = true
+	}
+	return &IdempotencyInterceptor{svc: svc, methods: set}
+}
+
+func (i *IdempotencyInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(IdempotencyKeyMetadata)
+		msg, ok := req.(proto.Message)
+		if !i.methods[info.FullMethod] || len(keys) == 0 || !ok {
+			return handler(ctx, req)
+		}
+		key := keys[0]
+
+		scope, err := idempotency.Scope(ctx)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		fingerprint := idempotency.Fingerprint([]byte(info.FullMethod), payload)
+
+		rec, err := i.svc.Begin(ctx, scope, key, fingerprint)
+		switch {
+		case errors.Is(err, domain.ErrInvalidKey):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrKeyReused):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, domain.ErrRequestInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case err != nil:
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		if rec != nil {
+			_ = grpc.SetHeader(ctx, metadata.Pairs("idempotent-replayed", "true"))
+			return replay(rec)
+		}
+
+		saveCtx := context.WithoutCancel(ctx)
+		resp, callErr := handler(ctx, req)
+		if err := i.save(saveCtx, scope, key, resp, callErr); err != nil {
+			_ = i.svc.Release(saveCtx, scope, key)
+		}
+		return resp, callErr
+	}
+}
+
+func (i *IdempotencyInterceptor) save(ctx context.Context, scope, key string, resp interface{}, callErr error) error {
+	if callErr != nil {
+		st := status.Convert(callErr)
+		if st.Code() == codes.Internal || st.Code() == codes.Unavailable || st.Code() == codes.Unknown {
+			return callErr
+		}
+		return i.svc.Complete(ctx, scope, key, int(st.Code()), "", []byte(st.Message()))
+	}
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return errors.New("response is not a proto message")
+	}
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return i.svc.Complete(ctx, scope, key, int(codes.OK), string(msg.ProtoReflect().Descriptor().FullName()), body)
+}
+
+func replay(rec *domain.Record) (interface{}, error) {
+	if codes.Code(rec.StatusCode) != codes.OK {
+		return nil, status.Error(codes.Code(rec.StatusCode), string(rec.Body))
+	}
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(rec.ContentType))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	msg := mt.New().Interface()
+	if err := proto.Unmarshal(rec.Body, msg); err != nil {
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return msg, nil
+}
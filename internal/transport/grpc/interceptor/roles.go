@@ -0,0 +1,26 @@
+package interceptor
+
+import (
+	"pvz-service/internal/domain/user"
+	"pvz-service/internal/transport/grpc/pb"
+)
+
+var PublicMethods = []string{
+	pb.AuthService_DummyLogin_FullMethodName,
+	pb.AuthService_Register_FullMethodName,
+	pb.AuthService_Login_FullMethodName,
+}
+
+var MethodRoles = map[string][]string{
+	pb.PVZService_GetPVZList_FullMethodName:         {user.RoleClient, user.RoleModerator},
+	pb.PVZService_CreatePVZ_FullMethodName:          {user.RoleModerator},
+	pb.PVZService_OpenReception_FullMethodName:      {user.RoleClient},
+	pb.PVZService_AddProduct_FullMethodName:         {user.RoleClient},
+	pb.PVZService_DeleteLastProduct_FullMethodName:  {user.RoleClient},
+	pb.PVZService_DeleteProduct_FullMethodName:      {user.RoleClient},
+	pb.PVZService_CloseLastReception_FullMethodName: {user.RoleClient},
+	pb.PVZService_ReopenReception_FullMethodName:    {user.RoleModerator},
+	pb.PVZService_CancelReception_FullMethodName:    {user.RoleModerator},
+	pb.PVZService_FindNearestPVZ_FullMethodName:     {user.RoleClient, user.RoleModerator},
+	pb.PVZService_WatchPVZ_FullMethodName:           {user.RoleClient, user.RoleModerator},
+}
@@ -27,6 +27,8 @@ type ReceptionStatus int32
 const (
 	ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS ReceptionStatus = 0
 	ReceptionStatus_RECEPTION_STATUS_CLOSED      ReceptionStatus = 1
+	ReceptionStatus_RECEPTION_STATUS_CANCELLED   ReceptionStatus = 2
+	ReceptionStatus_RECEPTION_STATUS_REOPENED    ReceptionStatus = 3
 )
 
 // Enum value maps for ReceptionStatus.
@@ -34,10 +36,14 @@ var (
 	ReceptionStatus_name = map[int32]string{
 		0: "RECEPTION_STATUS_IN_PROGRESS",
 		1: "RECEPTION_STATUS_CLOSED",
+		2: "RECEPTION_STATUS_CANCELLED",
+		3: "RECEPTION_STATUS_REOPENED",
 	}
 	ReceptionStatus_value = map[string]int32{
 		"RECEPTION_STATUS_IN_PROGRESS": 0,
 		"RECEPTION_STATUS_CLOSED":      1,
+		"RECEPTION_STATUS_CANCELLED":   2,
+		"RECEPTION_STATUS_REOPENED":    3,
 	}
 )
 
@@ -68,30 +74,1417 @@ func (ReceptionStatus) EnumDescriptor() ([]byte, []int) {
 	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{0}
 }
 
+type PVZEventType int32
+
+const (
+	PVZEventType_PVZ_EVENT_TYPE_UNSPECIFIED         PVZEventType = 0
+	PVZEventType_PVZ_EVENT_TYPE_RECEPTION_OPENED    PVZEventType = 1
+	PVZEventType_PVZ_EVENT_TYPE_RECEPTION_CLOSED    PVZEventType = 2
+	PVZEventType_PVZ_EVENT_TYPE_PRODUCT_ADDED       PVZEventType = 3
+	PVZEventType_PVZ_EVENT_TYPE_PRODUCT_REMOVED     PVZEventType = 4
+	PVZEventType_PVZ_EVENT_TYPE_RECEPTION_REOPENED  PVZEventType = 5
+	PVZEventType_PVZ_EVENT_TYPE_RECEPTION_CANCELLED PVZEventType = 6
+)
+
+// Enum value maps for PVZEventType.
+var (
+	PVZEventType_name = map[int32]string{
+		0: "PVZ_EVENT_TYPE_UNSPECIFIED",
+		1: "PVZ_EVENT_TYPE_RECEPTION_OPENED",
+		2: "PVZ_EVENT_TYPE_RECEPTION_CLOSED",
+		3: "PVZ_EVENT_TYPE_PRODUCT_ADDED",
+		4: "PVZ_EVENT_TYPE_PRODUCT_REMOVED",
+		5: "PVZ_EVENT_TYPE_RECEPTION_REOPENED",
+		6: "PVZ_EVENT_TYPE_RECEPTION_CANCELLED",
+	}
+	PVZEventType_value = map[string]int32{
+		"PVZ_EVENT_TYPE_UNSPECIFIED":         0,
+		"PVZ_EVENT_TYPE_RECEPTION_OPENED":    1,
+		"PVZ_EVENT_TYPE_RECEPTION_CLOSED":    2,
+		"PVZ_EVENT_TYPE_PRODUCT_ADDED":       3,
+		"PVZ_EVENT_TYPE_PRODUCT_REMOVED":     4,
+		"PVZ_EVENT_TYPE_RECEPTION_REOPENED":  5,
+		"PVZ_EVENT_TYPE_RECEPTION_CANCELLED": 6,
+	}
+)
+
+func (x PVZEventType) Enum() *PVZEventType {
+	p := new(PVZEventType)
+	*p = x
+	return p
+}
+
+func (x PVZEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PVZEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_pvz_proto_enumTypes[1].Descriptor()
+}
+
+func (PVZEventType) Type() protoreflect.EnumType {
+	return &file_api_grpc_pvz_proto_enumTypes[1]
+}
+
+func (x PVZEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PVZEventType.Descriptor instead.
+func (PVZEventType) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{1}
+}
+
+// DateFilter selects what from and to apply to.
+type DateFilter int32
+
+const (
+	DateFilter_DATE_FILTER_RECEPTION DateFilter = 0
+	DateFilter_DATE_FILTER_PRODUCT   DateFilter = 1
+	DateFilter_DATE_FILTER_PVZ       DateFilter = 2
+)
+
+// Enum value maps for DateFilter.
+var (
+	DateFilter_name = map[int32]string{
+		0: "DATE_FILTER_RECEPTION",
+		1: "DATE_FILTER_PRODUCT",
+		2: "DATE_FILTER_PVZ",
+	}
+	DateFilter_value = map[string]int32{
+		"DATE_FILTER_RECEPTION": 0,
+		"DATE_FILTER_PRODUCT":   1,
+		"DATE_FILTER_PVZ":       2,
+	}
+)
+
+func (x DateFilter) Enum() *DateFilter {
+	p := new(DateFilter)
+	*p = x
+	return p
+}
+
+func (x DateFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DateFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_grpc_pvz_proto_enumTypes[2].Descriptor()
+}
+
+func (DateFilter) Type() protoreflect.EnumType {
+	return &file_api_grpc_pvz_proto_enumTypes[2]
+}
+
+func (x DateFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DateFilter.Descriptor instead.
+func (DateFilter) EnumDescriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{2}
+}
+
 type PVZ struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	RegistrationDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
-	City             string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Id                         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RegistrationDate           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=registration_date,json=registrationDate,proto3" json:"registration_date,omitempty"`
+	City                       string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Timezone                   string                 `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	StaleReceptionAfterMinutes int32                  `protobuf:"varint,5,opt,name=stale_reception_after_minutes,json=staleReceptionAfterMinutes,proto3" json:"stale_reception_after_minutes,omitempty"`
+	DecommissionedAt           *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=decommissioned_at,json=decommissionedAt,proto3" json:"decommissioned_at,omitempty"`
+	Name                       string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	Address                    string                 `protobuf:"bytes,8,opt,name=address,proto3" json:"address,omitempty"`
+	// keyed by mon, tue, ... sun; a missing day is a day off
+	WorkingHours  map[string]*OpeningHours `protobuf:"bytes,9,rep,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Coordinates   *Coordinates             `protobuf:"bytes,10,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PVZ) Reset() {
+	*x = PVZ{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PVZ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PVZ) ProtoMessage() {}
+
+func (x *PVZ) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PVZ.ProtoReflect.Descriptor instead.
+func (*PVZ) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PVZ) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PVZ) GetRegistrationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegistrationDate
+	}
+	return nil
+}
+
+func (x *PVZ) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *PVZ) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *PVZ) GetStaleReceptionAfterMinutes() int32 {
+	if x != nil {
+		return x.StaleReceptionAfterMinutes
+	}
+	return 0
+}
+
+func (x *PVZ) GetDecommissionedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DecommissionedAt
+	}
+	return nil
+}
+
+func (x *PVZ) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PVZ) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PVZ) GetWorkingHours() map[string]*OpeningHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *PVZ) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type OpeningHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Open          string                 `protobuf:"bytes,1,opt,name=open,proto3" json:"open,omitempty"`
+	Close         string                 `protobuf:"bytes,2,opt,name=close,proto3" json:"close,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpeningHours) Reset() {
+	*x = OpeningHours{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpeningHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpeningHours) ProtoMessage() {}
+
+func (x *OpeningHours) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpeningHours.ProtoReflect.Descriptor instead.
+func (*OpeningHours) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OpeningHours) GetOpen() string {
+	if x != nil {
+		return x.Open
+	}
+	return ""
+}
+
+func (x *OpeningHours) GetClose() string {
+	if x != nil {
+		return x.Close
+	}
+	return ""
+}
+
+type Coordinates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Coordinates) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+type Reception struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DateTime      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	PvzId         string                 `protobuf:"bytes,3,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Status        ReceptionStatus        `protobuf:"varint,4,opt,name=status,proto3,enum=pvz.ReceptionStatus" json:"status,omitempty"`
+	ClosedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	OpenedBy      string                 `protobuf:"bytes,6,opt,name=opened_by,json=openedBy,proto3" json:"opened_by,omitempty"`
+	ClosedBy      string                 `protobuf:"bytes,7,opt,name=closed_by,json=closedBy,proto3" json:"closed_by,omitempty"`
+	ProductCount  int32                  `protobuf:"varint,8,opt,name=product_count,json=productCount,proto3" json:"product_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reception) Reset() {
+	*x = Reception{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reception) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reception) ProtoMessage() {}
+
+func (x *Reception) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reception.ProtoReflect.Descriptor instead.
+func (*Reception) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Reception) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reception) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Reception) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *Reception) GetStatus() ReceptionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS
+}
+
+func (x *Reception) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Reception) GetOpenedBy() string {
+	if x != nil {
+		return x.OpenedBy
+	}
+	return ""
+}
+
+func (x *Reception) GetClosedBy() string {
+	if x != nil {
+		return x.ClosedBy
+	}
+	return ""
+}
+
+func (x *Reception) GetProductCount() int32 {
+	if x != nil {
+		return x.ProductCount
+	}
+	return 0
+}
+
+type Product struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DateTime             *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	Type                 string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	ReceptionId          string                 `protobuf:"bytes,4,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	Barcode              string                 `protobuf:"bytes,5,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Sku                  string                 `protobuf:"bytes,6,opt,name=sku,proto3" json:"sku,omitempty"`
+	WeightGrams          int32                  `protobuf:"varint,7,opt,name=weight_grams,json=weightGrams,proto3" json:"weight_grams,omitempty"`
+	DeclaredValueKopecks int64                  `protobuf:"varint,8,opt,name=declared_value_kopecks,json=declaredValueKopecks,proto3" json:"declared_value_kopecks,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetDateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateTime
+	}
+	return nil
+}
+
+func (x *Product) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Product) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+func (x *Product) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *Product) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Product) GetWeightGrams() int32 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
+func (x *Product) GetDeclaredValueKopecks() int64 {
+	if x != nil {
+		return x.DeclaredValueKopecks
+	}
+	return 0
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type GetPVZListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// 10 when unset, at most 30
+	Limit                 int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor                string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	ClosedFrom            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=closed_from,json=closedFrom,proto3" json:"closed_from,omitempty"`
+	ClosedTo              *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=closed_to,json=closedTo,proto3" json:"closed_to,omitempty"`
+	OpenedBy              string                 `protobuf:"bytes,6,opt,name=opened_by,json=openedBy,proto3" json:"opened_by,omitempty"`
+	ClosedBy              string                 `protobuf:"bytes,7,opt,name=closed_by,json=closedBy,proto3" json:"closed_by,omitempty"`
+	IncludeDecommissioned bool                   `protobuf:"varint,8,opt,name=include_decommissioned,json=includeDecommissioned,proto3" json:"include_decommissioned,omitempty"`
+	// matched case-insensitively, aliases included
+	City string `protobuf:"bytes,9,opt,name=city,proto3" json:"city,omitempty"`
+	// a PVZ is listed when one of its receptions matches all reception filters
+	ReceptionStatuses []ReceptionStatus      `protobuf:"varint,10,rep,packed,name=reception_statuses,json=receptionStatuses,proto3,enum=pvz.ReceptionStatus" json:"reception_statuses,omitempty"`
+	ProductType       string                 `protobuf:"bytes,11,opt,name=product_type,json=productType,proto3" json:"product_type,omitempty"`
+	MinProducts       *int32                 `protobuf:"varint,12,opt,name=min_products,json=minProducts,proto3,oneof" json:"min_products,omitempty"`
+	MaxProducts       *int32                 `protobuf:"varint,13,opt,name=max_products,json=maxProducts,proto3,oneof" json:"max_products,omitempty"`
+	HasOpenReception  *bool                  `protobuf:"varint,14,opt,name=has_open_reception,json=hasOpenReception,proto3,oneof" json:"has_open_reception,omitempty"`
+	From              *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=from,proto3" json:"from,omitempty"`
+	To                *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=to,proto3" json:"to,omitempty"`
+	DateFilter        DateFilter             `protobuf:"varint,17,opt,name=date_filter,json=dateFilter,proto3,enum=pvz.DateFilter" json:"date_filter,omitempty"`
+	// list PVZs that have no receptions matching the filters too
+	IncludeEmpty  bool `protobuf:"varint,18,opt,name=include_empty,json=includeEmpty,proto3" json:"include_empty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPVZListRequest) Reset() {
+	*x = GetPVZListRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPVZListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPVZListRequest) ProtoMessage() {}
+
+func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPVZListRequest.ProtoReflect.Descriptor instead.
+func (*GetPVZListRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPVZListRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetPVZListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetPVZListRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetPVZListRequest) GetClosedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedFrom
+	}
+	return nil
+}
+
+func (x *GetPVZListRequest) GetClosedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedTo
+	}
+	return nil
+}
+
+func (x *GetPVZListRequest) GetOpenedBy() string {
+	if x != nil {
+		return x.OpenedBy
+	}
+	return ""
+}
+
+func (x *GetPVZListRequest) GetClosedBy() string {
+	if x != nil {
+		return x.ClosedBy
+	}
+	return ""
+}
+
+func (x *GetPVZListRequest) GetIncludeDecommissioned() bool {
+	if x != nil {
+		return x.IncludeDecommissioned
+	}
+	return false
+}
+
+func (x *GetPVZListRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetPVZListRequest) GetReceptionStatuses() []ReceptionStatus {
+	if x != nil {
+		return x.ReceptionStatuses
+	}
+	return nil
+}
+
+func (x *GetPVZListRequest) GetProductType() string {
+	if x != nil {
+		return x.ProductType
+	}
+	return ""
+}
+
+func (x *GetPVZListRequest) GetMinProducts() int32 {
+	if x != nil && x.MinProducts != nil {
+		return *x.MinProducts
+	}
+	return 0
+}
+
+func (x *GetPVZListRequest) GetMaxProducts() int32 {
+	if x != nil && x.MaxProducts != nil {
+		return *x.MaxProducts
+	}
+	return 0
+}
+
+func (x *GetPVZListRequest) GetHasOpenReception() bool {
+	if x != nil && x.HasOpenReception != nil {
+		return *x.HasOpenReception
+	}
+	return false
+}
+
+func (x *GetPVZListRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetPVZListRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetPVZListRequest) GetDateFilter() DateFilter {
+	if x != nil {
+		return x.DateFilter
+	}
+	return DateFilter_DATE_FILTER_RECEPTION
+}
+
+func (x *GetPVZListRequest) GetIncludeEmpty() bool {
+	if x != nil {
+		return x.IncludeEmpty
+	}
+	return false
+}
+
+type GetPVZListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvzs          []*PVZ                 `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPVZListResponse) Reset() {
+	*x = GetPVZListResponse{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPVZListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPVZListResponse) ProtoMessage() {}
+
+func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
+func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPVZListResponse) GetPvzs() []*PVZ {
+	if x != nil {
+		return x.Pvzs
+	}
+	return nil
+}
+
+func (x *GetPVZListResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CreatePVZRequest struct {
+	state                      protoimpl.MessageState   `protogen:"open.v1"`
+	City                       string                   `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Timezone                   string                   `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	StaleReceptionAfterMinutes int32                    `protobuf:"varint,3,opt,name=stale_reception_after_minutes,json=staleReceptionAfterMinutes,proto3" json:"stale_reception_after_minutes,omitempty"`
+	Name                       string                   `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Address                    string                   `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	WorkingHours               map[string]*OpeningHours `protobuf:"bytes,6,rep,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Coordinates                *Coordinates             `protobuf:"bytes,7,opt,name=coordinates,proto3" json:"coordinates,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *CreatePVZRequest) Reset() {
+	*x = CreatePVZRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePVZRequest) ProtoMessage() {}
+
+func (x *CreatePVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePVZRequest.ProtoReflect.Descriptor instead.
+func (*CreatePVZRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreatePVZRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CreatePVZRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *CreatePVZRequest) GetStaleReceptionAfterMinutes() int32 {
+	if x != nil {
+		return x.StaleReceptionAfterMinutes
+	}
+	return 0
+}
+
+func (x *CreatePVZRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePVZRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreatePVZRequest) GetWorkingHours() map[string]*OpeningHours {
+	if x != nil {
+		return x.WorkingHours
+	}
+	return nil
+}
+
+func (x *CreatePVZRequest) GetCoordinates() *Coordinates {
+	if x != nil {
+		return x.Coordinates
+	}
+	return nil
+}
+
+type FindNearestPVZRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *Coordinates           `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// zero means no distance limit
+	RadiusKm      float64 `protobuf:"fixed64,2,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	Limit         int32   `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindNearestPVZRequest) Reset() {
+	*x = FindNearestPVZRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindNearestPVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearestPVZRequest) ProtoMessage() {}
+
+func (x *FindNearestPVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearestPVZRequest.ProtoReflect.Descriptor instead.
+func (*FindNearestPVZRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FindNearestPVZRequest) GetFrom() *Coordinates {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *FindNearestPVZRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *FindNearestPVZRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type NearbyPVZ struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvz           *PVZ                   `protobuf:"bytes,1,opt,name=pvz,proto3" json:"pvz,omitempty"`
+	DistanceKm    float64                `protobuf:"fixed64,2,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearbyPVZ) Reset() {
+	*x = NearbyPVZ{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearbyPVZ) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyPVZ) ProtoMessage() {}
+
+func (x *NearbyPVZ) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyPVZ.ProtoReflect.Descriptor instead.
+func (*NearbyPVZ) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NearbyPVZ) GetPvz() *PVZ {
+	if x != nil {
+		return x.Pvz
+	}
+	return nil
+}
+
+func (x *NearbyPVZ) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+type FindNearestPVZResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pvzs          []*NearbyPVZ           `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindNearestPVZResponse) Reset() {
+	*x = FindNearestPVZResponse{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindNearestPVZResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindNearestPVZResponse) ProtoMessage() {}
+
+func (x *FindNearestPVZResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindNearestPVZResponse.ProtoReflect.Descriptor instead.
+func (*FindNearestPVZResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FindNearestPVZResponse) GetPvzs() []*NearbyPVZ {
+	if x != nil {
+		return x.Pvzs
+	}
+	return nil
+}
+
+type OpenReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenReceptionRequest) Reset() {
+	*x = OpenReceptionRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenReceptionRequest) ProtoMessage() {}
+
+func (x *OpenReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenReceptionRequest.ProtoReflect.Descriptor instead.
+func (*OpenReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OpenReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type AddProductRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	PvzId                string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	Type                 string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Barcode              string                 `protobuf:"bytes,3,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	Sku                  string                 `protobuf:"bytes,4,opt,name=sku,proto3" json:"sku,omitempty"`
+	WeightGrams          int32                  `protobuf:"varint,5,opt,name=weight_grams,json=weightGrams,proto3" json:"weight_grams,omitempty"`
+	DeclaredValueKopecks int64                  `protobuf:"varint,6,opt,name=declared_value_kopecks,json=declaredValueKopecks,proto3" json:"declared_value_kopecks,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *AddProductRequest) Reset() {
+	*x = AddProductRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductRequest) ProtoMessage() {}
+
+func (x *AddProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductRequest.ProtoReflect.Descriptor instead.
+func (*AddProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *AddProductRequest) GetWeightGrams() int32 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
+func (x *AddProductRequest) GetDeclaredValueKopecks() int64 {
+	if x != nil {
+		return x.DeclaredValueKopecks
+	}
+	return 0
+}
+
+type DeleteLastProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLastProductRequest) Reset() {
+	*x = DeleteLastProductRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLastProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLastProductRequest) ProtoMessage() {}
+
+func (x *DeleteLastProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLastProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLastProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteLastProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteProductRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *DeleteProductRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type CloseLastReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PvzId         string                 `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseLastReceptionRequest) Reset() {
+	*x = CloseLastReceptionRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseLastReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseLastReceptionRequest) ProtoMessage() {}
+
+func (x *CloseLastReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseLastReceptionRequest.ProtoReflect.Descriptor instead.
+func (*CloseLastReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CloseLastReceptionRequest) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+type ReopenReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId   string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReopenReceptionRequest) Reset() {
+	*x = ReopenReceptionRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PVZ) Reset() {
-	*x = PVZ{}
-	mi := &file_api_grpc_pvz_proto_msgTypes[0]
+func (x *ReopenReceptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReopenReceptionRequest) ProtoMessage() {}
+
+func (x *ReopenReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReopenReceptionRequest.ProtoReflect.Descriptor instead.
+func (*ReopenReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReopenReceptionRequest) GetReceptionId() string {
+	if x != nil {
+		return x.ReceptionId
+	}
+	return ""
+}
+
+type CancelReceptionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReceptionId   string                 `protobuf:"bytes,1,opt,name=reception_id,json=receptionId,proto3" json:"reception_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReceptionRequest) Reset() {
+	*x = CancelReceptionRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PVZ) String() string {
+func (x *CancelReceptionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PVZ) ProtoMessage() {}
+func (*CancelReceptionRequest) ProtoMessage() {}
 
-func (x *PVZ) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_pvz_proto_msgTypes[0]
+func (x *CancelReceptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -102,53 +1495,225 @@ func (x *PVZ) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PVZ.ProtoReflect.Descriptor instead.
-func (*PVZ) Descriptor() ([]byte, []int) {
-	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use CancelReceptionRequest.ProtoReflect.Descriptor instead.
+func (*CancelReceptionRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *PVZ) GetId() string {
+func (x *CancelReceptionRequest) GetReceptionId() string {
 	if x != nil {
-		return x.Id
+		return x.ReceptionId
 	}
 	return ""
 }
 
-func (x *PVZ) GetRegistrationDate() *timestamppb.Timestamp {
+// after_sequence = 0 streams only new events; otherwise the events after the
+// given sequence are replayed first. Sequences are shared by all server
+// instances and survive restarts.
+type WatchPVZRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Target:
+	//
+	//	*WatchPVZRequest_PvzId
+	//	*WatchPVZRequest_City
+	Target        isWatchPVZRequest_Target `protobuf_oneof:"target"`
+	AfterSequence uint64                   `protobuf:"varint,3,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPVZRequest) Reset() {
+	*x = WatchPVZRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPVZRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPVZRequest) ProtoMessage() {}
+
+func (x *WatchPVZRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[19]
 	if x != nil {
-		return x.RegistrationDate
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPVZRequest.ProtoReflect.Descriptor instead.
+func (*WatchPVZRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchPVZRequest) GetTarget() isWatchPVZRequest_Target {
+	if x != nil {
+		return x.Target
 	}
 	return nil
 }
 
-func (x *PVZ) GetCity() string {
+func (x *WatchPVZRequest) GetPvzId() string {
+	if x != nil {
+		if x, ok := x.Target.(*WatchPVZRequest_PvzId); ok {
+			return x.PvzId
+		}
+	}
+	return ""
+}
+
+func (x *WatchPVZRequest) GetCity() string {
+	if x != nil {
+		if x, ok := x.Target.(*WatchPVZRequest_City); ok {
+			return x.City
+		}
+	}
+	return ""
+}
+
+func (x *WatchPVZRequest) GetAfterSequence() uint64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+type isWatchPVZRequest_Target interface {
+	isWatchPVZRequest_Target()
+}
+
+type WatchPVZRequest_PvzId struct {
+	PvzId string `protobuf:"bytes,1,opt,name=pvz_id,json=pvzId,proto3,oneof"`
+}
+
+type WatchPVZRequest_City struct {
+	City string `protobuf:"bytes,2,opt,name=city,proto3,oneof"`
+}
+
+func (*WatchPVZRequest_PvzId) isWatchPVZRequest_Target() {}
+
+func (*WatchPVZRequest_City) isWatchPVZRequest_Target() {}
+
+type PVZEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Type          PVZEventType           `protobuf:"varint,2,opt,name=type,proto3,enum=pvz.PVZEventType" json:"type,omitempty"`
+	PvzId         string                 `protobuf:"bytes,3,opt,name=pvz_id,json=pvzId,proto3" json:"pvz_id,omitempty"`
+	City          string                 `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	Reception     *Reception             `protobuf:"bytes,5,opt,name=reception,proto3" json:"reception,omitempty"`
+	Product       *Product               `protobuf:"bytes,6,opt,name=product,proto3" json:"product,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PVZEvent) Reset() {
+	*x = PVZEvent{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PVZEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PVZEvent) ProtoMessage() {}
+
+func (x *PVZEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PVZEvent.ProtoReflect.Descriptor instead.
+func (*PVZEvent) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PVZEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *PVZEvent) GetType() PVZEventType {
+	if x != nil {
+		return x.Type
+	}
+	return PVZEventType_PVZ_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *PVZEvent) GetPvzId() string {
+	if x != nil {
+		return x.PvzId
+	}
+	return ""
+}
+
+func (x *PVZEvent) GetCity() string {
 	if x != nil {
 		return x.City
 	}
 	return ""
 }
 
-type GetPVZListRequest struct {
+func (x *PVZEvent) GetReception() *Reception {
+	if x != nil {
+		return x.Reception
+	}
+	return nil
+}
+
+func (x *PVZEvent) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *PVZEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type DummyLoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPVZListRequest) Reset() {
-	*x = GetPVZListRequest{}
-	mi := &file_api_grpc_pvz_proto_msgTypes[1]
+func (x *DummyLoginRequest) Reset() {
+	*x = DummyLoginRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPVZListRequest) String() string {
+func (x *DummyLoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPVZListRequest) ProtoMessage() {}
+func (*DummyLoginRequest) ProtoMessage() {}
 
-func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_pvz_proto_msgTypes[1]
+func (x *DummyLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,33 +1724,42 @@ func (x *GetPVZListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPVZListRequest.ProtoReflect.Descriptor instead.
-func (*GetPVZListRequest) Descriptor() ([]byte, []int) {
-	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use DummyLoginRequest.ProtoReflect.Descriptor instead.
+func (*DummyLoginRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{21}
 }
 
-type GetPVZListResponse struct {
+func (x *DummyLoginRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pvzs          []*PVZ                 `protobuf:"bytes,1,rep,name=pvzs,proto3" json:"pvzs,omitempty"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetPVZListResponse) Reset() {
-	*x = GetPVZListResponse{}
-	mi := &file_api_grpc_pvz_proto_msgTypes[2]
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPVZListResponse) String() string {
+func (x *RegisterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPVZListResponse) ProtoMessage() {}
+func (*RegisterRequest) ProtoMessage() {}
 
-func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_grpc_pvz_proto_msgTypes[2]
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -196,37 +1770,310 @@ func (x *GetPVZListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPVZListResponse.ProtoReflect.Descriptor instead.
-func (*GetPVZListResponse) Descriptor() ([]byte, []int) {
-	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *GetPVZListResponse) GetPvzs() []*PVZ {
+func (x *RegisterRequest) GetEmail() string {
 	if x != nil {
-		return x.Pvzs
+		return x.Email
 	}
-	return nil
+	return ""
+}
+
+func (x *RegisterRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type TokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenResponse) Reset() {
+	*x = TokenResponse{}
+	mi := &file_api_grpc_pvz_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenResponse) ProtoMessage() {}
+
+func (x *TokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_pvz_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenResponse.ProtoReflect.Descriptor instead.
+func (*TokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_pvz_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
 }
 
 var File_api_grpc_pvz_proto protoreflect.FileDescriptor
 
 const file_api_grpc_pvz_proto_rawDesc = "" +
 	"\n" +
-	"\x12api/grpc/pvz.proto\x12\x03pvz\x1a\x1fgoogle/protobuf/timestamp.proto\"r\n" +
+	"\x12api/grpc/pvz.proto\x12\x03pvz\x1a\x1fgoogle/protobuf/timestamp.proto\"\x91\x04\n" +
 	"\x03PVZ\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
 	"\x11registration_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x10registrationDate\x12\x12\n" +
-	"\x04city\x18\x03 \x01(\tR\x04city\"\x13\n" +
-	"\x11GetPVZListRequest\"2\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12A\n" +
+	"\x1dstale_reception_after_minutes\x18\x05 \x01(\x05R\x1astaleReceptionAfterMinutes\x12G\n" +
+	"\x11decommissioned_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10decommissionedAt\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\b \x01(\tR\aaddress\x12?\n" +
+	"\rworking_hours\x18\t \x03(\v2\x1a.pvz.PVZ.WorkingHoursEntryR\fworkingHours\x122\n" +
+	"\vcoordinates\x18\n" +
+	" \x01(\v2\x10.pvz.CoordinatesR\vcoordinates\x1aR\n" +
+	"\x11WorkingHoursEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.pvz.OpeningHoursR\x05value:\x028\x01\"8\n" +
+	"\fOpeningHours\x12\x12\n" +
+	"\x04open\x18\x01 \x01(\tR\x04open\x12\x14\n" +
+	"\x05close\x18\x02 \x01(\tR\x05close\"G\n" +
+	"\vCoordinates\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\xb1\x02\n" +
+	"\tReception\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12\x15\n" +
+	"\x06pvz_id\x18\x03 \x01(\tR\x05pvzId\x12,\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x14.pvz.ReceptionStatusR\x06status\x127\n" +
+	"\tclosed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12\x1b\n" +
+	"\topened_by\x18\x06 \x01(\tR\bopenedBy\x12\x1b\n" +
+	"\tclosed_by\x18\a \x01(\tR\bclosedBy\x12#\n" +
+	"\rproduct_count\x18\b \x01(\x05R\fproductCount\"\x8e\x02\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tdate_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdateTime\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12!\n" +
+	"\freception_id\x18\x04 \x01(\tR\vreceptionId\x12\x18\n" +
+	"\abarcode\x18\x05 \x01(\tR\abarcode\x12\x10\n" +
+	"\x03sku\x18\x06 \x01(\tR\x03sku\x12!\n" +
+	"\fweight_grams\x18\a \x01(\x05R\vweightGrams\x124\n" +
+	"\x16declared_value_kopecks\x18\b \x01(\x03R\x14declaredValueKopecks\"@\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"\xa7\x06\n" +
+	"\x11GetPVZListRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12;\n" +
+	"\vclosed_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"closedFrom\x127\n" +
+	"\tclosed_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bclosedTo\x12\x1b\n" +
+	"\topened_by\x18\x06 \x01(\tR\bopenedBy\x12\x1b\n" +
+	"\tclosed_by\x18\a \x01(\tR\bclosedBy\x125\n" +
+	"\x16include_decommissioned\x18\b \x01(\bR\x15includeDecommissioned\x12\x12\n" +
+	"\x04city\x18\t \x01(\tR\x04city\x12C\n" +
+	"\x12reception_statuses\x18\n" +
+	" \x03(\x0e2\x14.pvz.ReceptionStatusR\x11receptionStatuses\x12!\n" +
+	"\fproduct_type\x18\v \x01(\tR\vproductType\x12&\n" +
+	"\fmin_products\x18\f \x01(\x05H\x00R\vminProducts\x88\x01\x01\x12&\n" +
+	"\fmax_products\x18\r \x01(\x05H\x01R\vmaxProducts\x88\x01\x01\x121\n" +
+	"\x12has_open_reception\x18\x0e \x01(\bH\x02R\x10hasOpenReception\x88\x01\x01\x12.\n" +
+	"\x04from\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x120\n" +
+	"\vdate_filter\x18\x11 \x01(\x0e2\x0f.pvz.DateFilterR\n" +
+	"dateFilter\x12#\n" +
+	"\rinclude_empty\x18\x12 \x01(\bR\fincludeEmptyB\x0f\n" +
+	"\r_min_productsB\x0f\n" +
+	"\r_max_productsB\x15\n" +
+	"\x13_has_open_reception\"S\n" +
 	"\x12GetPVZListResponse\x12\x1c\n" +
-	"\x04pvzs\x18\x01 \x03(\v2\b.pvz.PVZR\x04pvzs*P\n" +
+	"\x04pvzs\x18\x01 \x03(\v2\b.pvz.PVZR\x04pvzs\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\x89\x03\n" +
+	"\x10CreatePVZRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x1a\n" +
+	"\btimezone\x18\x02 \x01(\tR\btimezone\x12A\n" +
+	"\x1dstale_reception_after_minutes\x18\x03 \x01(\x05R\x1astaleReceptionAfterMinutes\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\x12L\n" +
+	"\rworking_hours\x18\x06 \x03(\v2'.pvz.CreatePVZRequest.WorkingHoursEntryR\fworkingHours\x122\n" +
+	"\vcoordinates\x18\a \x01(\v2\x10.pvz.CoordinatesR\vcoordinates\x1aR\n" +
+	"\x11WorkingHoursEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.pvz.OpeningHoursR\x05value:\x028\x01\"p\n" +
+	"\x15FindNearestPVZRequest\x12$\n" +
+	"\x04from\x18\x01 \x01(\v2\x10.pvz.CoordinatesR\x04from\x12\x1b\n" +
+	"\tradius_km\x18\x02 \x01(\x01R\bradiusKm\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"H\n" +
+	"\tNearbyPVZ\x12\x1a\n" +
+	"\x03pvz\x18\x01 \x01(\v2\b.pvz.PVZR\x03pvz\x12\x1f\n" +
+	"\vdistance_km\x18\x02 \x01(\x01R\n" +
+	"distanceKm\"<\n" +
+	"\x16FindNearestPVZResponse\x12\"\n" +
+	"\x04pvzs\x18\x01 \x03(\v2\x0e.pvz.NearbyPVZR\x04pvzs\"-\n" +
+	"\x14OpenReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"\xc3\x01\n" +
+	"\x11AddProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\abarcode\x18\x03 \x01(\tR\abarcode\x12\x10\n" +
+	"\x03sku\x18\x04 \x01(\tR\x03sku\x12!\n" +
+	"\fweight_grams\x18\x05 \x01(\x05R\vweightGrams\x124\n" +
+	"\x16declared_value_kopecks\x18\x06 \x01(\x03R\x14declaredValueKopecks\"1\n" +
+	"\x18DeleteLastProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\"L\n" +
+	"\x14DeleteProductRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\"2\n" +
+	"\x19CloseLastReceptionRequest\x12\x15\n" +
+	"\x06pvz_id\x18\x01 \x01(\tR\x05pvzId\";\n" +
+	"\x16ReopenReceptionRequest\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\";\n" +
+	"\x16CancelReceptionRequest\x12!\n" +
+	"\freception_id\x18\x01 \x01(\tR\vreceptionId\"q\n" +
+	"\x0fWatchPVZRequest\x12\x17\n" +
+	"\x06pvz_id\x18\x01 \x01(\tH\x00R\x05pvzId\x12\x14\n" +
+	"\x04city\x18\x02 \x01(\tH\x00R\x04city\x12%\n" +
+	"\x0eafter_sequence\x18\x03 \x01(\x04R\rafterSequenceB\b\n" +
+	"\x06target\"\x8b\x02\n" +
+	"\bPVZEvent\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12%\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x11.pvz.PVZEventTypeR\x04type\x12\x15\n" +
+	"\x06pvz_id\x18\x03 \x01(\tR\x05pvzId\x12\x12\n" +
+	"\x04city\x18\x04 \x01(\tR\x04city\x12,\n" +
+	"\treception\x18\x05 \x01(\v2\x0e.pvz.ReceptionR\treception\x12&\n" +
+	"\aproduct\x18\x06 \x01(\v2\f.pvz.ProductR\aproduct\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"'\n" +
+	"\x11DummyLoginRequest\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\"W\n" +
+	"\x0fRegisterRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"@\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"%\n" +
+	"\rTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token*\x8f\x01\n" +
 	"\x0fReceptionStatus\x12 \n" +
 	"\x1cRECEPTION_STATUS_IN_PROGRESS\x10\x00\x12\x1b\n" +
-	"\x17RECEPTION_STATUS_CLOSED\x10\x012K\n" +
+	"\x17RECEPTION_STATUS_CLOSED\x10\x01\x12\x1e\n" +
+	"\x1aRECEPTION_STATUS_CANCELLED\x10\x02\x12\x1d\n" +
+	"\x19RECEPTION_STATUS_REOPENED\x10\x03*\x8d\x02\n" +
+	"\fPVZEventType\x12\x1e\n" +
+	"\x1aPVZ_EVENT_TYPE_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fPVZ_EVENT_TYPE_RECEPTION_OPENED\x10\x01\x12#\n" +
+	"\x1fPVZ_EVENT_TYPE_RECEPTION_CLOSED\x10\x02\x12 \n" +
+	"\x1cPVZ_EVENT_TYPE_PRODUCT_ADDED\x10\x03\x12\"\n" +
+	"\x1ePVZ_EVENT_TYPE_PRODUCT_REMOVED\x10\x04\x12%\n" +
+	"!PVZ_EVENT_TYPE_RECEPTION_REOPENED\x10\x05\x12&\n" +
+	"\"PVZ_EVENT_TYPE_RECEPTION_CANCELLED\x10\x06*U\n" +
+	"\n" +
+	"DateFilter\x12\x19\n" +
+	"\x15DATE_FILTER_RECEPTION\x10\x00\x12\x17\n" +
+	"\x13DATE_FILTER_PRODUCT\x10\x01\x12\x13\n" +
+	"\x0fDATE_FILTER_PVZ\x10\x022\xa9\x05\n" +
 	"\n" +
 	"PVZService\x12=\n" +
 	"\n" +
-	"GetPVZList\x12\x16.pvz.GetPVZListRequest\x1a\x17.pvz.GetPVZListResponseB\x1fZ\x1dinternal/transport/grpc/pb;pbb\x06proto3"
+	"GetPVZList\x12\x16.pvz.GetPVZListRequest\x1a\x17.pvz.GetPVZListResponse\x12,\n" +
+	"\tCreatePVZ\x12\x15.pvz.CreatePVZRequest\x1a\b.pvz.PVZ\x12:\n" +
+	"\rOpenReception\x12\x19.pvz.OpenReceptionRequest\x1a\x0e.pvz.Reception\x122\n" +
+	"\n" +
+	"AddProduct\x12\x16.pvz.AddProductRequest\x1a\f.pvz.Product\x12@\n" +
+	"\x11DeleteLastProduct\x12\x1d.pvz.DeleteLastProductRequest\x1a\f.pvz.Product\x128\n" +
+	"\rDeleteProduct\x12\x19.pvz.DeleteProductRequest\x1a\f.pvz.Product\x12D\n" +
+	"\x12CloseLastReception\x12\x1e.pvz.CloseLastReceptionRequest\x1a\x0e.pvz.Reception\x12>\n" +
+	"\x0fReopenReception\x12\x1b.pvz.ReopenReceptionRequest\x1a\x0e.pvz.Reception\x12>\n" +
+	"\x0fCancelReception\x12\x1b.pvz.CancelReceptionRequest\x1a\x0e.pvz.Reception\x12I\n" +
+	"\x0eFindNearestPVZ\x12\x1a.pvz.FindNearestPVZRequest\x1a\x1b.pvz.FindNearestPVZResponse\x121\n" +
+	"\bWatchPVZ\x12\x14.pvz.WatchPVZRequest\x1a\r.pvz.PVZEvent0\x012\xa4\x01\n" +
+	"\vAuthService\x128\n" +
+	"\n" +
+	"DummyLogin\x12\x16.pvz.DummyLoginRequest\x1a\x12.pvz.TokenResponse\x12+\n" +
+	"\bRegister\x12\x14.pvz.RegisterRequest\x1a\t.pvz.User\x12.\n" +
+	"\x05Login\x12\x11.pvz.LoginRequest\x1a\x12.pvz.TokenResponseB\x1fZ\x1dinternal/transport/grpc/pb;pbb\x06proto3"
 
 var (
 	file_api_grpc_pvz_proto_rawDescOnce sync.Once
@@ -240,25 +2087,101 @@ func file_api_grpc_pvz_proto_rawDescGZIP() []byte {
 	return file_api_grpc_pvz_proto_rawDescData
 }
 
-var file_api_grpc_pvz_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_grpc_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_grpc_pvz_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_grpc_pvz_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_api_grpc_pvz_proto_goTypes = []any{
-	(ReceptionStatus)(0),          // 0: pvz.ReceptionStatus
-	(*PVZ)(nil),                   // 1: pvz.PVZ
-	(*GetPVZListRequest)(nil),     // 2: pvz.GetPVZListRequest
-	(*GetPVZListResponse)(nil),    // 3: pvz.GetPVZListResponse
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(ReceptionStatus)(0),              // 0: pvz.ReceptionStatus
+	(PVZEventType)(0),                 // 1: pvz.PVZEventType
+	(DateFilter)(0),                   // 2: pvz.DateFilter
+	(*PVZ)(nil),                       // 3: pvz.PVZ
+	(*OpeningHours)(nil),              // 4: pvz.OpeningHours
+	(*Coordinates)(nil),               // 5: pvz.Coordinates
+	(*Reception)(nil),                 // 6: pvz.Reception
+	(*Product)(nil),                   // 7: pvz.Product
+	(*User)(nil),                      // 8: pvz.User
+	(*GetPVZListRequest)(nil),         // 9: pvz.GetPVZListRequest
+	(*GetPVZListResponse)(nil),        // 10: pvz.GetPVZListResponse
+	(*CreatePVZRequest)(nil),          // 11: pvz.CreatePVZRequest
+	(*FindNearestPVZRequest)(nil),     // 12: pvz.FindNearestPVZRequest
+	(*NearbyPVZ)(nil),                 // 13: pvz.NearbyPVZ
+	(*FindNearestPVZResponse)(nil),    // 14: pvz.FindNearestPVZResponse
+	(*OpenReceptionRequest)(nil),      // 15: pvz.OpenReceptionRequest
+	(*AddProductRequest)(nil),         // 16: pvz.AddProductRequest
+	(*DeleteLastProductRequest)(nil),  // 17: pvz.DeleteLastProductRequest
+	(*DeleteProductRequest)(nil),      // 18: pvz.DeleteProductRequest
+	(*CloseLastReceptionRequest)(nil), // 19: pvz.CloseLastReceptionRequest
+	(*ReopenReceptionRequest)(nil),    // 20: pvz.ReopenReceptionRequest
+	(*CancelReceptionRequest)(nil),    // 21: pvz.CancelReceptionRequest
+	(*WatchPVZRequest)(nil),           // 22: pvz.WatchPVZRequest
+	(*PVZEvent)(nil),                  // 23: pvz.PVZEvent
+	(*DummyLoginRequest)(nil),         // 24: pvz.DummyLoginRequest
+	(*RegisterRequest)(nil),           // 25: pvz.RegisterRequest
+	(*LoginRequest)(nil),              // 26: pvz.LoginRequest
+	(*TokenResponse)(nil),             // 27: pvz.TokenResponse
+	nil,                               // 28: pvz.PVZ.WorkingHoursEntry
+	nil,                               // 29: pvz.CreatePVZRequest.WorkingHoursEntry
+	(*timestamppb.Timestamp)(nil),     // 30: google.protobuf.Timestamp
 }
 var file_api_grpc_pvz_proto_depIdxs = []int32{
-	4, // 0: pvz.PVZ.registration_date:type_name -> google.protobuf.Timestamp
-	1, // 1: pvz.GetPVZListResponse.pvzs:type_name -> pvz.PVZ
-	2, // 2: pvz.PVZService.GetPVZList:input_type -> pvz.GetPVZListRequest
-	3, // 3: pvz.PVZService.GetPVZList:output_type -> pvz.GetPVZListResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	30, // 0: pvz.PVZ.registration_date:type_name -> google.protobuf.Timestamp
+	30, // 1: pvz.PVZ.decommissioned_at:type_name -> google.protobuf.Timestamp
+	28, // 2: pvz.PVZ.working_hours:type_name -> pvz.PVZ.WorkingHoursEntry
+	5,  // 3: pvz.PVZ.coordinates:type_name -> pvz.Coordinates
+	30, // 4: pvz.Reception.date_time:type_name -> google.protobuf.Timestamp
+	0,  // 5: pvz.Reception.status:type_name -> pvz.ReceptionStatus
+	30, // 6: pvz.Reception.closed_at:type_name -> google.protobuf.Timestamp
+	30, // 7: pvz.Product.date_time:type_name -> google.protobuf.Timestamp
+	30, // 8: pvz.GetPVZListRequest.closed_from:type_name -> google.protobuf.Timestamp
+	30, // 9: pvz.GetPVZListRequest.closed_to:type_name -> google.protobuf.Timestamp
+	0,  // 10: pvz.GetPVZListRequest.reception_statuses:type_name -> pvz.ReceptionStatus
+	30, // 11: pvz.GetPVZListRequest.from:type_name -> google.protobuf.Timestamp
+	30, // 12: pvz.GetPVZListRequest.to:type_name -> google.protobuf.Timestamp
+	2,  // 13: pvz.GetPVZListRequest.date_filter:type_name -> pvz.DateFilter
+	3,  // 14: pvz.GetPVZListResponse.pvzs:type_name -> pvz.PVZ
+	29, // 15: pvz.CreatePVZRequest.working_hours:type_name -> pvz.CreatePVZRequest.WorkingHoursEntry
+	5,  // 16: pvz.CreatePVZRequest.coordinates:type_name -> pvz.Coordinates
+	5,  // 17: pvz.FindNearestPVZRequest.from:type_name -> pvz.Coordinates
+	3,  // 18: pvz.NearbyPVZ.pvz:type_name -> pvz.PVZ
+	13, // 19: pvz.FindNearestPVZResponse.pvzs:type_name -> pvz.NearbyPVZ
+	1,  // 20: pvz.PVZEvent.type:type_name -> pvz.PVZEventType
+	6,  // 21: pvz.PVZEvent.reception:type_name -> pvz.Reception
+	7,  // 22: pvz.PVZEvent.product:type_name -> pvz.Product
+	30, // 23: pvz.PVZEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	4,  // 24: pvz.PVZ.WorkingHoursEntry.value:type_name -> pvz.OpeningHours
+	4,  // 25: pvz.CreatePVZRequest.WorkingHoursEntry.value:type_name -> pvz.OpeningHours
+	9,  // 26: pvz.PVZService.GetPVZList:input_type -> pvz.GetPVZListRequest
+	11, // 27: pvz.PVZService.CreatePVZ:input_type -> pvz.CreatePVZRequest
+	15, // 28: pvz.PVZService.OpenReception:input_type -> pvz.OpenReceptionRequest
+	16, // 29: pvz.PVZService.AddProduct:input_type -> pvz.AddProductRequest
+	17, // 30: pvz.PVZService.DeleteLastProduct:input_type -> pvz.DeleteLastProductRequest
+	18, // 31: pvz.PVZService.DeleteProduct:input_type -> pvz.DeleteProductRequest
+	19, // 32: pvz.PVZService.CloseLastReception:input_type -> pvz.CloseLastReceptionRequest
+	20, // 33: pvz.PVZService.ReopenReception:input_type -> pvz.ReopenReceptionRequest
+	21, // 34: pvz.PVZService.CancelReception:input_type -> pvz.CancelReceptionRequest
+	12, // 35: pvz.PVZService.FindNearestPVZ:input_type -> pvz.FindNearestPVZRequest
+	22, // 36: pvz.PVZService.WatchPVZ:input_type -> pvz.WatchPVZRequest
+	24, // 37: pvz.AuthService.DummyLogin:input_type -> pvz.DummyLoginRequest
+	25, // 38: pvz.AuthService.Register:input_type -> pvz.RegisterRequest
+	26, // 39: pvz.AuthService.Login:input_type -> pvz.LoginRequest
+	10, // 40: pvz.PVZService.GetPVZList:output_type -> pvz.GetPVZListResponse
+	3,  // 41: pvz.PVZService.CreatePVZ:output_type -> pvz.PVZ
+	6,  // 42: pvz.PVZService.OpenReception:output_type -> pvz.Reception
+	7,  // 43: pvz.PVZService.AddProduct:output_type -> pvz.Product
+	7,  // 44: pvz.PVZService.DeleteLastProduct:output_type -> pvz.Product
+	7,  // 45: pvz.PVZService.DeleteProduct:output_type -> pvz.Product
+	6,  // 46: pvz.PVZService.CloseLastReception:output_type -> pvz.Reception
+	6,  // 47: pvz.PVZService.ReopenReception:output_type -> pvz.Reception
+	6,  // 48: pvz.PVZService.CancelReception:output_type -> pvz.Reception
+	14, // 49: pvz.PVZService.FindNearestPVZ:output_type -> pvz.FindNearestPVZResponse
+	23, // 50: pvz.PVZService.WatchPVZ:output_type -> pvz.PVZEvent
+	27, // 51: pvz.AuthService.DummyLogin:output_type -> pvz.TokenResponse
+	8,  // 52: pvz.AuthService.Register:output_type -> pvz.User
+	27, // 53: pvz.AuthService.Login:output_type -> pvz.TokenResponse
+	40, // [40:54] is the sub-list for method output_type
+	26, // [26:40] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_pvz_proto_init() }
@@ -266,15 +2189,20 @@ func file_api_grpc_pvz_proto_init() {
 	if File_api_grpc_pvz_proto != nil {
 		return
 	}
+	file_api_grpc_pvz_proto_msgTypes[6].OneofWrappers = []any{}
+	file_api_grpc_pvz_proto_msgTypes[19].OneofWrappers = []any{
+		(*WatchPVZRequest_PvzId)(nil),
+		(*WatchPVZRequest_City)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_grpc_pvz_proto_rawDesc), len(file_api_grpc_pvz_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      3,
+			NumMessages:   27,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_grpc_pvz_proto_goTypes,
 		DependencyIndexes: file_api_grpc_pvz_proto_depIdxs,
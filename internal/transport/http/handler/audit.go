@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	auditdomain "pvz-service/internal/domain/audit"
+	audituc "pvz-service/internal/usecase/audit"
+
+	"github.com/google/uuid"
+)
+
+type AuditHandler struct {
+	auditService *audituc.Service
+}
+
+func NewAuditHandler(auditService *audituc.Service) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+type apiAuditEntry struct {
+	ID          string            `json:"id"`
+	OccurredAt  time.Time         `json:"occurredAt"`
+	ActorID     string            `json:"actorId"`
+	ActorRole   string            `json:"actorRole"`
+	Action      string            `json:"action"`
+	PVZID       string            `json:"pvzId,omitempty"`
+	ReceptionID string            `json:"receptionId,omitempty"`
+	ProductID   string            `json:"productId,omitempty"`
+	Details     map[string]string `json:"details"`
+}
+
+func toAPIAuditEntry(e auditdomain.Entry) apiAuditEntry {
+	return apiAuditEntry{
+		ID:          e.ID,
+		OccurredAt:  e.OccurredAt,
+		ActorID:     e.ActorID,
+		ActorRole:   e.ActorRole,
+		Action:      string(e.Action),
+		PVZID:       e.PVZID,
+		ReceptionID: e.ReceptionID,
+		ProductID:   e.ProductID,
+		Details:     e.Details,
+	}
+}
+
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	params := audituc.ListParams{
+		PVZID:   q.Get("pvzId"),
+		ActorID: q.Get("actorId"),
+	}
+	if params.PVZID != "" {
+		if _, err := uuid.Parse(params.PVZID); err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+	}
+	if s := q.Get("startDate"); s != "" {
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		params.From = &tm
+	}
+	if s := q.Get("endDate"); s != "" {
+		tm, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		params.To = &tm
+	}
+	if l := q.Get("limit"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil || v < 1 || v > audituc.MaxLimit {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		params.Limit = v
+	}
+
+	entries, err := h.auditService.List(r.Context(), params)
+	if err != nil {
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiAuditEntry, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, toAPIAuditEntry(e))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"pvz-service/internal/domain/product"
+	pvzdomain "pvz-service/internal/domain/pvz"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type apiCapacity struct {
+	MaxProducts int            `json:"maxProducts"`
+	MaxPerType  map[string]int `json:"maxPerType"`
+}
+
+func toAPICapacity(c pvzdomain.Capacity) apiCapacity {
+	resp := apiCapacity{MaxProducts: c.MaxProducts, MaxPerType: c.MaxPerType}
+	if resp.MaxPerType == nil {
+		resp.MaxPerType = map[string]int{}
+	}
+	return resp
+}
+
+func (h *PVZHandler) GetCapacity(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if pvzID == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	c, err := h.pvzService.GetCapacity(r.Context(), pvzID)
+	if err != nil {
+		if errors.Is(err, pvzdomain.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toAPICapacity(c))
+}
+
+// SetCapacity accepts product types by code or by any localized name.
+func (h *PVZHandler) SetCapacity(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	var req apiCapacity
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || pvzID == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	c := pvzdomain.Capacity{MaxProducts: req.MaxProducts, MaxPerType: make(map[string]int, len(req.MaxPerType))}
+	for name, n := range req.MaxPerType {
+		code, err := h.catalog.Resolve(r.Context(), name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.MaxPerType[code] = n
+	}
+
+	saved, err := h.pvzService.SetCapacity(r.Context(), pvzID, c)
+	if err != nil {
+		switch {
+		case errors.Is(err, pvzdomain.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, pvzdomain.ErrInvalidCapacity), errors.Is(err, product.ErrInvalidType), errors.Is(err, product.ErrAmbiguousType):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Internal Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toAPICapacity(saved))
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/usecase/city"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CityHandler struct {
+	cityService *city.Service
+}
+
+func NewCityHandler(cityService *city.Service) *CityHandler {
+	return &CityHandler{cityService: cityService}
+}
+
+type apiCity struct {
+	Name      string            `json:"name"`
+	Aliases   map[string]string `json:"aliases"`
+	Timezone  string            `json:"timezone"`
+	Active    bool              `json:"active"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+func toAPICity(c *pvz.City) apiCity {
+	return apiCity{
+		Name:      c.Name,
+		Aliases:   c.Aliases,
+		Timezone:  c.Timezone,
+		Active:    c.Active,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func writeCityError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pvz.ErrInvalidCityName), errors.Is(err, pvz.ErrInvalidTimezone):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, pvz.ErrCityNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, pvz.ErrCityExists), errors.Is(err, pvz.ErrCityInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+	}
+}
+
+func cityNameParam(r *http.Request) string {
+	name, err := url.PathUnescape(chi.URLParam(r, "name"))
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+func (h *CityHandler) List(w http.ResponseWriter, r *http.Request) {
+	cities, err := h.cityService.List(r.Context())
+	if err != nil {
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiCity, 0, len(cities))
+	for i := range cities {
+		resp = append(resp, toAPICity(&cities[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *CityHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name     string            `json:"name"`
+		Aliases  map[string]string `json:"aliases"`
+		Timezone string            `json:"timezone"`
+		Active   *bool             `json:"active"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	c, err := h.cityService.Create(r.Context(), city.CreateParams{
+		Name:     req.Name,
+		Aliases:  req.Aliases,
+		Timezone: req.Timezone,
+		Active:   active,
+	})
+	if err != nil {
+		writeCityError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toAPICity(c))
+}
+
+func (h *CityHandler) Update(w http.ResponseWriter, r *http.Request) {
+	name := cityNameParam(r)
+	if name == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Aliases  map[string]string `json:"aliases"`
+		Timezone *string           `json:"timezone"`
+		Active   *bool             `json:"active"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	c, err := h.cityService.Update(r.Context(), name, city.UpdateParams{
+		Aliases:  req.Aliases,
+		Timezone: req.Timezone,
+		Active:   req.Active,
+	})
+	if err != nil {
+		writeCityError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toAPICity(c))
+}
+
+func (h *CityHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	name := cityNameParam(r)
+	if name == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.cityService.Delete(r.Context(), name); err != nil {
+		writeCityError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	receptionuc "pvz-service/internal/usecase/reception"
+)
+
+type apiBatchItemResult struct {
+	Index   int         `json:"index"`
+	Status  string      `json:"status"`
+	Product *apiProduct `json:"product,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+type apiBatchResult struct {
+	Mode  string               `json:"mode"`
+	Added int                  `json:"added"`
+	Items []apiBatchItemResult `json:"items"`
+}
+
+func (h *PVZHandler) AddProductsBatch(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		PVZID string `json:"pvzId"`
+		Mode  string `json:"mode"`
+		Items []struct {
+			Type                 string `json:"type"`
+			Barcode              string `json:"barcode"`
+			SKU                  string `json:"sku"`
+			WeightGrams          int    `json:"weightGrams"`
+			DeclaredValueKopecks int64  `json:"declaredValueKopecks"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PVZID == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+	mode := receptionuc.BatchMode(req.Mode)
+	if mode == "" {
+		mode = receptionuc.BatchAllOrNothing
+	}
+
+	items := make([]receptionuc.BatchItem, 0, len(req.Items))
+	for _, it := range req.Items {
+		// an unknown name is passed through and reported for its item
+		internalType, err := h.catalog.Resolve(r.Context(), it.Type)
+		if err != nil {
+			internalType = it.Type
+		}
+		items = append(items, receptionuc.BatchItem{
+			Type: internalType,
+			Details: receptionuc.ProductDetails{
+				Barcode:              it.Barcode,
+				SKU:                  it.SKU,
+				WeightGrams:          it.WeightGrams,
+				DeclaredValueKopecks: it.DeclaredValueKopecks,
+			},
+		})
+	}
+
+	results, err := h.receptionService.AddProducts(r.Context(), req.PVZID, items, mode)
+	if err != nil && !errors.Is(err, receptionuc.ErrBatchRejected) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := apiBatchResult{Mode: string(mode), Items: make([]apiBatchItemResult, 0, len(results))}
+	for i, res := range results {
+		item := apiBatchItemResult{Index: i}
+		if res.Err != nil {
+			item.Status = "failed"
+			item.Error = res.Err.Error()
+		} else {
+			item.Status = "added"
+			item.Product = h.toAPIProduct(r, res.Product)
+			resp.Added++
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	status := http.StatusCreated
+	switch {
+	case err != nil:
+		status = http.StatusUnprocessableEntity
+	case resp.Added < len(results):
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/usecase/catalog"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ProductTypeHandler struct {
+	catalog *catalog.Service
+}
+
+func NewProductTypeHandler(catalogService *catalog.Service) *ProductTypeHandler {
+	return &ProductTypeHandler{catalog: catalogService}
+}
+
+type apiProductType struct {
+	Code      string            `json:"code"`
+	Names     map[string]string `json:"names"`
+	Active    bool              `json:"active"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+func toAPIProductType(t *product.Type) apiProductType {
+	return apiProductType{
+		Code:      t.Code,
+		Names:     t.Names,
+		Active:    t.Active,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func productTypeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, product.ErrInvalidTypeCode):
+		return http.StatusBadRequest
+	case errors.Is(err, product.ErrTypeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, product.ErrTypeExists), errors.Is(err, product.ErrTypeInUse):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeProductTypeError(w http.ResponseWriter, err error) {
+	status := productTypeErrorStatus(err)
+	if status == http.StatusInternalServerError {
+		http.Error(w, "Internal Error", status)
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func (h *ProductTypeHandler) List(w http.ResponseWriter, r *http.Request) {
+	types, err := h.catalog.List(r.Context())
+	if err != nil {
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiProductType, 0, len(types))
+	for i := range types {
+		resp = append(resp, toAPIProductType(&types[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *ProductTypeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Code   string            `json:"code"`
+		Names  map[string]string `json:"names"`
+		Active *bool             `json:"active"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	t, err := h.catalog.Create(r.Context(), catalog.CreateParams{
+		Code:   req.Code,
+		Names:  req.Names,
+		Active: active,
+	})
+	if err != nil {
+		writeProductTypeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toAPIProductType(t))
+}
+
+func (h *ProductTypeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	if code == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Names  map[string]string `json:"names"`
+		Active *bool             `json:"active"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	t, err := h.catalog.Update(r.Context(), code, catalog.UpdateParams{
+		Names:  req.Names,
+		Active: req.Active,
+	})
+	if err != nil {
+		writeProductTypeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toAPIProductType(t))
+}
+
+func (h *ProductTypeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	if code == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.catalog.Delete(r.Context(), code); err != nil {
+		writeProductTypeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,76 +2,252 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"pvz-service/internal/domain/product"
+	pvzdomain "pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/usecase/catalog"
 	"pvz-service/internal/usecase/pvz"
 	receptionuc "pvz-service/internal/usecase/reception"
 
 	"github.com/go-chi/chi/v5"
 )
 
+const NextCursorHeader = "X-Next-Cursor"
+
+const (
+	timezoneUTC   = "utc"
+	timezoneLocal = "local"
+)
+
+var wallClockLayouts = []string{"2006-01-02T15:04:05.999999999", "2006-01-02"}
+
+// parseDateParam accepts RFC 3339 instants as well as date-times without an
+// offset; for the latter wall is true and the reading is returned in UTC.
+func parseDateParam(s string) (t time.Time, wall bool, ok bool) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, false, true
+	}
+	for _, layout := range wallClockLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+func renderLocation(timezone string, local bool) *time.Location {
+	if !local {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 type PVZHandler struct {
 	pvzService       *pvz.Service
 	receptionService *receptionuc.Service
+	catalog          *catalog.Service
 }
 
-func NewPVZHandler(pvzService *pvz.Service, receptionService *receptionuc.Service) *PVZHandler {
-	return &PVZHandler{pvzService: pvzService, receptionService: receptionService}
+func NewPVZHandler(pvzService *pvz.Service, receptionService *receptionuc.Service, catalogService *catalog.Service) *PVZHandler {
+	return &PVZHandler{pvzService: pvzService, receptionService: receptionService, catalog: catalogService}
 }
 
-func productTypeAPIToInternal(t string) (string, bool) {
-	switch t {
-	case "электроника":
-		return "electronics", true
-	case "одежда":
-		return "clothes", true
-	case "обувь":
-		return "shoes", true
-	default:
-		return "", false
+func receptionStatusInternalToAPI(s string) string {
+	if s == "closed" {
+		return "close"
 	}
+	return s
 }
 
-func productTypeInternalToAPI(t string) string {
-	switch t {
-	case "electronics":
-		return "электроника"
-	case "clothes":
-		return "одежда"
-	case "shoes":
-		return "обувь"
-	default:
-		return t
+func receptionStatusAPIToInternal(s string) string {
+	if s == "close" {
+		return "closed"
 	}
+	return s
 }
 
-func receptionStatusInternalToAPI(s string) string {
-	if s == "closed" {
-		return "close"
+// parseReceptionFilters reads the reception status and product type query
+// parameters shared by the PVZ list and detail endpoints.
+func (h *PVZHandler) parseReceptionFilters(r *http.Request) (statuses []string, productType string, err error) {
+	q := r.URL.Query()
+	if v := q.Get("receptionStatus"); v != "" {
+		for _, st := range strings.Split(v, ",") {
+			statuses = append(statuses, receptionStatusAPIToInternal(strings.TrimSpace(st)))
+		}
 	}
-	return s
+	if v := q.Get("productType"); v != "" {
+		if productType, err = h.catalog.Resolve(r.Context(), v); err != nil {
+			return nil, "", err
+		}
+	}
+	return statuses, productType, nil
+}
+
+func parseOptionalInt(s string) (*int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
 }
 
 type apiPVZ struct {
 	ID               string    `json:"id"`
 	RegistrationDate time.Time `json:"registrationDate"`
 	City             string    `json:"city"`
+	Timezone         string    `json:"timezone"`
+
+	StaleReceptionAfterMinutes int `json:"staleReceptionAfterMinutes,omitempty"`
+
+	Name             string                     `json:"name,omitempty"`
+	Address          string                     `json:"address,omitempty"`
+	WorkingHours     map[string]apiOpeningHours `json:"workingHours,omitempty"`
+	DecommissionedAt *time.Time                 `json:"decommissionedAt,omitempty"`
+	Latitude         *float64                   `json:"latitude,omitempty"`
+	Longitude        *float64                   `json:"longitude,omitempty"`
+}
+
+type apiOpeningHours struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+func toAPIPVZ(p pvz.PVZInfo, loc *time.Location) apiPVZ {
+	resp := apiPVZ{
+		ID:               p.ID,
+		RegistrationDate: p.CreatedAt.In(loc),
+		City:             p.City,
+		Timezone:         p.Timezone,
+
+		StaleReceptionAfterMinutes: int(p.StaleReceptionAfter / time.Minute),
+
+		Name:    p.Name,
+		Address: p.Address,
+	}
+	if len(p.WorkingHours) > 0 {
+		resp.WorkingHours = make(map[string]apiOpeningHours, len(p.WorkingHours))
+		for day, h := range p.WorkingHours {
+			resp.WorkingHours[day] = apiOpeningHours{Open: h.Open, Close: h.Close}
+		}
+	}
+	if p.DecommissionedAt != nil {
+		at := p.DecommissionedAt.In(loc)
+		resp.DecommissionedAt = &at
+	}
+	if p.Coordinates != nil {
+		resp.Latitude = &p.Coordinates.Latitude
+		resp.Longitude = &p.Coordinates.Longitude
+	}
+	return resp
+}
+
+func toSchedule(hours map[string]apiOpeningHours) pvzdomain.Schedule {
+	if hours == nil {
+		return nil
+	}
+	schedule := make(pvzdomain.Schedule, len(hours))
+	for day, h := range hours {
+		schedule[day] = pvzdomain.OpeningHours{Open: h.Open, Close: h.Close}
+	}
+	return schedule
+}
+
+// toCoordinates requires both parts or neither.
+func toCoordinates(lat, lon *float64) (*pvzdomain.Coordinates, bool) {
+	if lat == nil && lon == nil {
+		return nil, true
+	}
+	if lat == nil || lon == nil {
+		return nil, false
+	}
+	return &pvzdomain.Coordinates{Latitude: *lat, Longitude: *lon}, true
 }
 
 type apiReception struct {
-	ID       string    `json:"id"`
-	DateTime time.Time `json:"dateTime"`
-	PVZID    string    `json:"pvzId"`
-	Status   string    `json:"status"`
+	ID           string     `json:"id"`
+	DateTime     time.Time  `json:"dateTime"`
+	PVZID        string     `json:"pvzId"`
+	Status       string     `json:"status"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+	OpenedBy     string     `json:"openedBy,omitempty"`
+	ClosedBy     string     `json:"closedBy,omitempty"`
+	ProductCount int        `json:"productCount"`
 }
 
 type apiProduct struct {
-	ID          string    `json:"id"`
-	DateTime    time.Time `json:"dateTime"`
-	Type        string    `json:"type"`
-	ReceptionID string    `json:"receptionId"`
+	ID                   string    `json:"id"`
+	DateTime             time.Time `json:"dateTime"`
+	Type                 string    `json:"type"`
+	ReceptionID          string    `json:"receptionId"`
+	Barcode              string    `json:"barcode,omitempty"`
+	SKU                  string    `json:"sku,omitempty"`
+	WeightGrams          int       `json:"weightGrams,omitempty"`
+	DeclaredValueKopecks int64     `json:"declaredValueKopecks,omitempty"`
+}
+
+func (h *PVZHandler) toAPIProduct(r *http.Request, pr *product.Product) *apiProduct {
+	return &apiProduct{
+		ID:                   pr.ID,
+		DateTime:             pr.AddedAt,
+		Type:                 h.catalog.DisplayName(r.Context(), pr.Type, catalog.DefaultLocale),
+		ReceptionID:          pr.ReceptionID,
+		Barcode:              pr.Barcode,
+		SKU:                  pr.SKU,
+		WeightGrams:          pr.WeightGrams,
+		DeclaredValueKopecks: pr.DeclaredValueKopecks,
+	}
+}
+
+// toAPIProductInfo renders a product of the read model, which carries its
+// reception separately.
+func (h *PVZHandler) toAPIProductInfo(r *http.Request, receptionID string, pr pvz.ProductInfo) apiProduct {
+	return *h.toAPIProduct(r, &product.Product{
+		ID:          pr.ID,
+		ReceptionID: receptionID,
+		AddedAt:     pr.AddedAt,
+		Type:        pr.Type,
+
+		Barcode:              pr.Barcode,
+		SKU:                  pr.SKU,
+		WeightGrams:          pr.WeightGrams,
+		DeclaredValueKopecks: pr.DeclaredValueKopecks,
+	})
+}
+
+func toAPIReceptionInfo(pvzID string, rcv pvz.ReceptionInfo, loc *time.Location) apiReception {
+	rec := reception.Reception{
+		ID:           rcv.ID,
+		PVZID:        pvzID,
+		StartedAt:    rcv.StartedAt.In(loc),
+		Status:       rcv.Status,
+		OpenedBy:     rcv.OpenedBy,
+		ClosedBy:     rcv.ClosedBy,
+		ProductCount: rcv.ProductCount,
+	}
+	if rcv.ClosedAt != nil {
+		closedAt := rcv.ClosedAt.In(loc)
+		rec.ClosedAt = &closedAt
+	}
+	return toAPIReception(&rec)
+}
+
+type apiProductLocation struct {
+	Product   apiProduct   `json:"product"`
+	Reception apiReception `json:"reception"`
+	City      string       `json:"city"`
 }
 
 type apiPVZListItem struct {
@@ -84,24 +260,43 @@ type apiPVZListItem struct {
 
 func (h *PVZHandler) CreatePVZ(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		City string `json:"city"`
+		City     string `json:"city"`
+		Timezone string `json:"timezone"`
+
+		StaleReceptionAfterMinutes int `json:"staleReceptionAfterMinutes"`
+
+		Name         string                     `json:"name"`
+		Address      string                     `json:"address"`
+		WorkingHours map[string]apiOpeningHours `json:"workingHours"`
+		Latitude     *float64                   `json:"latitude"`
+		Longitude    *float64                   `json:"longitude"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.City == "" {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		return
 	}
+	coords, ok := toCoordinates(req.Latitude, req.Longitude)
+	if !ok {
+		http.Error(w, pvzdomain.ErrInvalidCoordinates.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.pvzService.Create(r.Context(), pvz.CreateParams{
+		City:                req.City,
+		Timezone:            req.Timezone,
+		StaleReceptionAfter: time.Duration(req.StaleReceptionAfterMinutes) * time.Minute,
 
-	result, err := h.pvzService.Create(r.Context(), req.City)
+		Name:         req.Name,
+		Address:      req.Address,
+		WorkingHours: toSchedule(req.WorkingHours),
+		Coordinates:  coords,
+	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	resp := apiPVZ{
-		ID:               result.ID,
-		RegistrationDate: result.CreatedAt,
-		City:             result.City,
-	}
+	resp := toAPIPVZ(*result, time.UTC)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -111,22 +306,58 @@ func (h *PVZHandler) CreatePVZ(w http.ResponseWriter, r *http.Request) {
 func (h *PVZHandler) ListPVZ(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 
-	var startDate, endDate *time.Time
+	tz := q.Get("tz")
+	if tz == "" {
+		tz = timezoneUTC
+	}
+	if tz != timezoneUTC && tz != timezoneLocal {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+	local := tz == timezoneLocal
+
+	var startDate, endDate, localStart, localEnd *time.Time
 	if s := q.Get("startDate"); s != "" {
-		tm, err := time.Parse(time.RFC3339Nano, s)
-		if err != nil {
+		tm, wall, ok := parseDateParam(s)
+		if !ok {
 			http.Error(w, "Bad Request", http.StatusBadRequest)
 			return
 		}
-		startDate = &tm
+		if wall && local {
+			localStart = &tm
+		} else {
+			startDate = &tm
+		}
 	}
 	if s := q.Get("endDate"); s != "" {
-		tm, err := time.Parse(time.RFC3339Nano, s)
+		tm, wall, ok := parseDateParam(s)
+		if !ok {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		if wall && local {
+			localEnd = &tm
+		} else {
+			endDate = &tm
+		}
+	}
+
+	var closedStart, closedEnd *time.Time
+	if s := q.Get("closedStartDate"); s != "" {
+		tm, err := time.Parse(time.RFC3339, s)
 		if err != nil {
 			http.Error(w, "Bad Request", http.StatusBadRequest)
 			return
 		}
-		endDate = &tm
+		closedStart = &tm
+	}
+	if s := q.Get("closedEndDate"); s != "" {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		closedEnd = &tm
 	}
 
 	page := 1
@@ -150,42 +381,108 @@ func (h *PVZHandler) ListPVZ(w http.ResponseWriter, r *http.Request) {
 	}
 	offset := (page - 1) * limit
 
-	result, err := h.pvzService.List(r.Context(), startDate, endDate, limit, offset)
+	cursor := q.Get("cursor")
+	if cursor != "" {
+		offset = 0
+	}
+
+	statuses, productType, err := h.parseReceptionFilters(r)
 	if err != nil {
-		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+	minProducts, err := parseOptionalInt(q.Get("minProducts"))
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+	maxProducts, err := parseOptionalInt(q.Get("maxProducts"))
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
 		return
 	}
+	var hasOpen *bool
+	if v := q.Get("hasOpenReception"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		hasOpen = &b
+	}
 
-	resp := make([]apiPVZListItem, 0, len(result))
-	for _, p := range result {
-		item := apiPVZListItem{
-			PVZ: apiPVZ{
-				ID:               p.ID,
-				RegistrationDate: p.CreatedAt,
-				City:             p.City,
-			},
+	includeEmpty := false
+	if v := q.Get("includeEmpty"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
 		}
+		includeEmpty = b
+	}
+
+	includeDecommissioned := false
+	if v := q.Get("includeDecommissioned"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		includeDecommissioned = b
+	}
+
+	result, err := h.pvzService.List(r.Context(), pvz.ListParams{
+		From:      startDate,
+		To:        endDate,
+		LocalFrom: localStart,
+		LocalTo:   localEnd,
+		Limit:     limit,
+		Offset:    offset,
+		Cursor:    cursor,
+
+		ClosedFrom: closedStart,
+		ClosedTo:   closedEnd,
+		OpenedBy:   q.Get("openedBy"),
+		ClosedBy:   q.Get("closedBy"),
+
+		IncludeDecommissioned: includeDecommissioned,
+
+		City:              q.Get("city"),
+		HasOpenReception:  hasOpen,
+		ReceptionStatuses: statuses,
+		ProductType:       productType,
+		MinProducts:       minProducts,
+		MaxProducts:       maxProducts,
+
+		DateField:    q.Get("dateFilter"),
+		IncludeEmpty: includeEmpty,
+	})
+	if err != nil {
+		if errors.Is(err, pvz.ErrInvalidCursor) || errors.Is(err, pvz.ErrInvalidFilter) {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiPVZListItem, 0, len(result.Items))
+	for _, p := range result.Items {
+		loc := renderLocation(p.Timezone, local)
+		item := apiPVZListItem{PVZ: toAPIPVZ(p, loc)}
 
 		for _, rcv := range p.Receptions {
 			block := struct {
 				Reception apiReception `json:"reception"`
 				Products  []apiProduct `json:"products"`
 			}{
-				Reception: apiReception{
-					ID:       rcv.ID,
-					DateTime: rcv.StartedAt,
-					PVZID:    p.ID,
-					Status:   receptionStatusInternalToAPI(rcv.Status),
-				},
+				Reception: toAPIReceptionInfo(p.ID, rcv, loc),
 			}
 
 			for _, pr := range rcv.Products {
-				block.Products = append(block.Products, apiProduct{
-					ID:          pr.ID,
-					DateTime:    pr.AddedAt,
-					Type:        productTypeInternalToAPI(pr.Type),
-					ReceptionID: rcv.ID,
-				})
+				prod := h.toAPIProductInfo(r, rcv.ID, pr)
+				prod.DateTime = prod.DateTime.In(loc)
+				block.Products = append(block.Products, prod)
 			}
 
 			item.Receptions = append(item.Receptions, block)
@@ -194,6 +491,9 @@ func (h *PVZHandler) ListPVZ(w http.ResponseWriter, r *http.Request) {
 		resp = append(resp, item)
 	}
 
+	if result.NextCursor != "" {
+		w.Header().Set(NextCursorHeader, result.NextCursor)
+	}
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(resp)
 }
@@ -213,46 +513,52 @@ func (h *PVZHandler) CreateReception(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := apiReception{
-		ID:       rec.ID,
-		DateTime: rec.StartedAt,
-		PVZID:    rec.PVZID,
-		Status:   "in_progress",
-	}
-
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	_ = json.NewEncoder(w).Encode(resp)
+	_ = json.NewEncoder(w).Encode(toAPIReception(rec))
 }
 
 func (h *PVZHandler) AddProduct(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		Type  string `json:"type"`
-		PVZID string `json:"pvzId"`
+		Type                 string `json:"type"`
+		PVZID                string `json:"pvzId"`
+		Barcode              string `json:"barcode"`
+		SKU                  string `json:"sku"`
+		WeightGrams          int    `json:"weightGrams"`
+		DeclaredValueKopecks int64  `json:"declaredValueKopecks"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Type == "" || req.PVZID == "" {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		return
 	}
 
-	internalType, ok := productTypeAPIToInternal(req.Type)
-	if !ok {
+	internalType, err := h.catalog.Resolve(r.Context(), req.Type)
+	if err != nil {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		return
 	}
 
-	pr, err := h.receptionService.AddProduct(r.Context(), req.PVZID, internalType)
+	var pr *product.Product
+	if req.Barcode != "" {
+		pr, err = h.receptionService.AddScannedProduct(r.Context(), req.PVZID, internalType, receptionuc.ProductDetails{
+			Barcode:              req.Barcode,
+			SKU:                  req.SKU,
+			WeightGrams:          req.WeightGrams,
+			DeclaredValueKopecks: req.DeclaredValueKopecks,
+		})
+	} else {
+		pr, err = h.receptionService.AddProduct(r.Context(), req.PVZID, internalType)
+	}
 	if err != nil {
+		if errors.Is(err, product.ErrDuplicateBarcode) || errors.Is(err, pvzdomain.ErrCapacityExceeded) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	resp := apiProduct{
-		ID:          pr.ID,
-		DateTime:    pr.AddedAt,
-		Type:        productTypeInternalToAPI(pr.Type),
-		ReceptionID: pr.ReceptionID,
-	}
+	resp := h.toAPIProduct(r, pr)
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -272,15 +578,8 @@ func (h *PVZHandler) CloseLastReception(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	resp := apiReception{
-		ID:       rec.ID,
-		DateTime: rec.StartedAt,
-		PVZID:    rec.PVZID,
-		Status:   "close",
-	}
-
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(resp)
+	_ = json.NewEncoder(w).Encode(toAPIReception(rec))
 }
 
 func (h *PVZHandler) DeleteLastProduct(w http.ResponseWriter, r *http.Request) {
@@ -298,3 +597,61 @@ func (h *PVZHandler) DeleteLastProduct(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+func (h *PVZHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	productID := chi.URLParam(r, "productId")
+	if pvzID == "" || productID == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.receptionService.RemoveProductByID(r.Context(), pvzID, productID)
+	if err != nil {
+		switch {
+		case errors.Is(err, product.ErrNotFound), errors.Is(err, pvzdomain.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	resp := h.toAPIProduct(r, pr)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *PVZHandler) FindProductByBarcode(w http.ResponseWriter, r *http.Request) {
+	code := chi.URLParam(r, "code")
+	if code == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	locations, err := h.pvzService.LocateProduct(r.Context(), code)
+	if err != nil {
+		switch {
+		case errors.Is(err, product.ErrInvalidBarcode):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, product.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, "Internal Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	resp := make([]apiProductLocation, 0, len(locations))
+	for _, l := range locations {
+		resp = append(resp, apiProductLocation{
+			Product:   h.toAPIProductInfo(r, l.Reception.ID, l.Product),
+			Reception: toAPIReception(&l.Reception),
+			City:      l.City,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	pvzdomain "pvz-service/internal/domain/pvz"
+	"pvz-service/internal/usecase/pvz"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+type apiPVZDetails struct {
+	PVZ            apiPVZ         `json:"pvz"`
+	Receptions     []apiReception `json:"receptions"`
+	ReceptionCount int            `json:"receptionCount"`
+	ProductCount   int            `json:"productCount"`
+}
+
+func pvzErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, pvzdomain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, pvzdomain.ErrInvalidName),
+		errors.Is(err, pvzdomain.ErrInvalidAddress),
+		errors.Is(err, pvzdomain.ErrInvalidSchedule),
+		errors.Is(err, pvzdomain.ErrInvalidCoordinates),
+		errors.Is(err, pvzdomain.ErrCityAmbiguous),
+		errors.Is(err, pvz.ErrInvalidRadius),
+		errors.Is(err, pvz.ErrInvalidFilter):
+		return http.StatusBadRequest
+	case errors.Is(err, pvzdomain.ErrDecommissioned),
+		errors.Is(err, pvzdomain.ErrHasOpenReception):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writePVZError(w http.ResponseWriter, err error) {
+	code := pvzErrorStatus(err)
+	if code == http.StatusInternalServerError {
+		http.Error(w, "Internal Error", code)
+		return
+	}
+	http.Error(w, err.Error(), code)
+}
+
+func (h *PVZHandler) GetPVZ(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	statuses, productType, err := h.parseReceptionFilters(r)
+	if err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	details, err := h.pvzService.Get(r.Context(), pvzID, pvz.DetailsParams{
+		ReceptionStatuses: statuses,
+		ProductType:       productType,
+	})
+	if err != nil {
+		writePVZError(w, err)
+		return
+	}
+
+	resp := apiPVZDetails{
+		PVZ:            toAPIPVZ(details.PVZInfo, time.UTC),
+		Receptions:     make([]apiReception, 0, len(details.Receptions)),
+		ReceptionCount: details.ReceptionCount,
+		ProductCount:   details.ProductCount,
+	}
+	for _, rcv := range details.Receptions {
+		resp.Receptions = append(resp.Receptions, toAPIReceptionInfo(details.ID, rcv, time.UTC))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *PVZHandler) UpdatePVZ(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	var req struct {
+		Name         *string                     `json:"name"`
+		Address      *string                     `json:"address"`
+		WorkingHours *map[string]apiOpeningHours `json:"workingHours"`
+		Latitude     *float64                    `json:"latitude"`
+		Longitude    *float64                    `json:"longitude"`
+	}
+	if _, err := uuid.Parse(pvzID); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	coords, ok := toCoordinates(req.Latitude, req.Longitude)
+	if !ok {
+		http.Error(w, pvzdomain.ErrInvalidCoordinates.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := pvz.UpdateParams{Name: req.Name, Address: req.Address, Coordinates: coords}
+	if req.WorkingHours != nil {
+		schedule := toSchedule(*req.WorkingHours)
+		params.WorkingHours = &schedule
+	}
+
+	result, err := h.pvzService.Update(r.Context(), pvzID, params)
+	if err != nil {
+		writePVZError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toAPIPVZ(*result, time.UTC))
+}
+
+func (h *PVZHandler) DecommissionPVZ(w http.ResponseWriter, r *http.Request) {
+	pvzID := chi.URLParam(r, "pvzId")
+	if _, err := uuid.Parse(pvzID); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.pvzService.Decommission(r.Context(), pvzID)
+	if err != nil {
+		writePVZError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toAPIPVZ(*result, time.UTC))
+}
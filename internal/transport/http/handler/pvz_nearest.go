@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	pvzdomain "pvz-service/internal/domain/pvz"
+	"pvz-service/internal/usecase/pvz"
+)
+
+type apiNearbyPVZ struct {
+	PVZ        apiPVZ  `json:"pvz"`
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+func (h *PVZHandler) FindNearestPVZ(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	lat, errLat := strconv.ParseFloat(q.Get("lat"), 64)
+	lon, errLon := strconv.ParseFloat(q.Get("lon"), 64)
+	if errLat != nil || errLon != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+	params := pvz.NearestParams{From: pvzdomain.Coordinates{Latitude: lat, Longitude: lon}}
+	if v := q.Get("radiusKm"); v != "" {
+		radius, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		params.RadiusKm = radius
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > pvz.MaxNearestLimit {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		params.Limit = limit
+	}
+
+	found, err := h.pvzService.Nearest(r.Context(), params)
+	if err != nil {
+		writePVZError(w, err)
+		return
+	}
+
+	resp := make([]apiNearbyPVZ, 0, len(found))
+	for _, f := range found {
+		resp = append(resp, apiNearbyPVZ{PVZ: toAPIPVZ(f.PVZInfo, time.UTC), DistanceKm: f.DistanceKm})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
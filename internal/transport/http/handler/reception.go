@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	pvzdomain "pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+func toAPIReception(rec *reception.Reception) apiReception {
+	return apiReception{
+		ID:           rec.ID,
+		DateTime:     rec.StartedAt,
+		PVZID:        rec.PVZID,
+		Status:       receptionStatusInternalToAPI(rec.Status),
+		ClosedAt:     rec.ClosedAt,
+		OpenedBy:     rec.OpenedBy,
+		ClosedBy:     rec.ClosedBy,
+		ProductCount: rec.ProductCount,
+	}
+}
+
+func (h *PVZHandler) ReopenReception(w http.ResponseWriter, r *http.Request) {
+	h.transitionReception(w, r, h.receptionService.Reopen)
+}
+
+func (h *PVZHandler) CancelReception(w http.ResponseWriter, r *http.Request) {
+	h.transitionReception(w, r, h.receptionService.Cancel)
+}
+
+func (h *PVZHandler) DeleteReception(w http.ResponseWriter, r *http.Request) {
+	receptionID := chi.URLParam(r, "receptionId")
+	if _, err := uuid.Parse(receptionID); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.receptionService.Delete(r.Context(), receptionID); err != nil {
+		switch {
+		case errors.Is(err, reception.ErrNotFound), errors.Is(err, pvzdomain.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, reception.ErrNotCancelled):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Internal Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PVZHandler) transitionReception(w http.ResponseWriter, r *http.Request, apply func(context.Context, string) (*reception.Reception, error)) {
+	receptionID := chi.URLParam(r, "receptionId")
+	if _, err := uuid.Parse(receptionID); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	rec, err := apply(r.Context(), receptionID)
+	if err != nil {
+		switch {
+		case errors.Is(err, reception.ErrNotFound), errors.Is(err, pvzdomain.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, reception.ErrInvalidTransition),
+			errors.Is(err, reception.ErrReopenWindowExpired),
+			errors.Is(err, reception.ErrReceptionNotEmpty),
+			errors.Is(err, reception.ErrReceptionAlreadyOpen),
+			errors.Is(err, pvzdomain.ErrDecommissioned):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, "Internal Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(toAPIReception(rec))
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pvz-service/internal/domain/webhook"
+	webhookuc "pvz-service/internal/usecase/webhook"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebhookHandler struct {
+	webhookService *webhookuc.Service
+}
+
+func NewWebhookHandler(webhookService *webhookuc.Service) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+type apiWebhook struct {
+	ID                  string     `json:"id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"eventTypes"`
+	PVZID               string     `json:"pvzId,omitempty"`
+	City                string     `json:"city,omitempty"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+}
+
+type apiWebhookDelivery struct {
+	ID             string          `json:"id"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode int             `json:"lastStatusCode,omitempty"`
+	LastError      string          `json:"lastError,omitempty"`
+	NextAttemptAt  time.Time       `json:"nextAttemptAt"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+func toAPIWebhook(s *webhook.Subscription) apiWebhook {
+	types := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		types = append(types, string(t))
+	}
+	return apiWebhook{
+		ID:                  s.ID,
+		URL:                 s.URL,
+		EventTypes:          types,
+		PVZID:               s.PVZID,
+		City:                s.City,
+		Active:              s.Active,
+		ConsecutiveFailures: s.ConsecutiveFailures,
+		CreatedAt:           s.CreatedAt,
+		DisabledAt:          s.DisabledAt,
+	}
+}
+
+func (h *WebhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		URL        string   `json:"url"`
+		EventTypes []string `json:"eventTypes"`
+		PVZID      string   `json:"pvzId"`
+		City       string   `json:"city"`
+		Secret     string   `json:"secret"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.webhookService.Create(r.Context(), webhookuc.CreateParams{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		PVZID:      req.PVZID,
+		City:       req.City,
+		Secret:     req.Secret,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(toAPIWebhook(sub))
+}
+
+func (h *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.webhookService.List(r.Context())
+	if err != nil {
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiWebhook, 0, len(subs))
+	for i := range subs {
+		resp = append(resp, toAPIWebhook(&subs[i]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "webhookId")
+	if id == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.webhookService.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, webhook.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "webhookId")
+	if id == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		v, err := strconv.Atoi(l)
+		if err != nil || v < 1 || v > 500 {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		limit = v
+	}
+
+	deliveries, err := h.webhookService.Deliveries(r.Context(), id, limit)
+	if err != nil {
+		if errors.Is(err, webhook.ErrNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Internal Error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]apiWebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, apiWebhookDelivery{
+			ID:             d.ID,
+			EventID:        d.EventID,
+			EventType:      string(d.EventType),
+			Status:         d.Status,
+			Attempts:       d.Attempts,
+			LastStatusCode: d.LastStatusCode,
+			LastError:      d.LastError,
+			NextAttemptAt:  d.NextAttemptAt,
+			CreatedAt:      d.CreatedAt,
+			DeliveredAt:    d.DeliveredAt,
+			Payload:        d.Payload,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"context"
 	"net/http"
 	"strings"
 
@@ -9,10 +8,6 @@ import (
 	"pvz-service/internal/usecase/ports"
 )
 
-type contextKey string
-
-const userContextKey contextKey = "authUser"
-
 func AuthMiddleware(tokenManager ports.TokenManager) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -27,8 +22,7 @@ func AuthMiddleware(tokenManager ports.TokenManager) func(next http.Handler) htt
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
-			ctx := context.WithValue(r.Context(), userContextKey, usr)
-			r = r.WithContext(ctx)
+			r = r.WithContext(user.WithContext(r.Context(), usr))
 			next.ServeHTTP(w, r)
 		})
 	}
@@ -41,12 +35,7 @@ func RequireRole(allowedRoles ...string) func(http.Handler) http.Handler {
 	}
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			val := r.Context().Value(userContextKey)
-			if val == nil {
-				http.Error(w, "Forbidden", http.StatusForbidden)
-				return
-			}
-			u, ok := val.(*user.User)
+			u, ok := user.FromContext(r.Context())
 			if !ok || !roleSet[u.Role] {
 				http.Error(w, "Forbidden", http.StatusForbidden)
 				return
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	domain "pvz-service/internal/domain/idempotency"
+	"pvz-service/internal/usecase/idempotency"
+)
+
+const (
+	IdempotencyKeyHeader   = "Idempotency-Key"
+	IdempotentReplayHeader = "Idempotent-Replayed"
+
+	maxIdempotentBody = 1 << 20
+)
+
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// IdempotencyMiddleware replays the stored response of a mutating request
+// retried with the same Idempotency-Key. It must run after the role check.
+// Keys are scoped to the user and role; reusing one for a different request is
+// rejected. Server errors are not stored so
+// that the client can retry them.
+func IdempotencyMiddleware(svc *idempotency.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scope, err := idempotency.Scope(r.Context())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBody+1))
+			if err != nil || len(body) > maxIdempotentBody {
+				http.Error(w, "Bad Request", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := idempotency.Fingerprint([]byte(r.Method), []byte(r.URL.Path), []byte(r.URL.RawQuery), body)
+
+			rec, err := svc.Begin(r.Context(), scope, key, fingerprint)
+			switch {
+			case errors.Is(err, domain.ErrInvalidKey):
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			case errors.Is(err, domain.ErrKeyReused):
+				http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+				return
+			case errors.Is(err, domain.ErrRequestInProgress):
+				http.Error(w, err.Error(), http.StatusConflict)
+				return
+			case err != nil:
+				http.Error(w, "Internal Error", http.StatusInternalServerError)
+				return
+			}
+			if rec != nil {
+				if rec.ContentType != "" {
+					w.Header().Set("Content-Type", rec.ContentType)
+				}
+				w.Header().Set(IdempotentReplayHeader, "true")
+				w.WriteHeader(rec.StatusCode)
+				_, _ = w.Write(rec.Body)
+				return
+			}
+
+			rw := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+			// the outcome is saved even if the client has already gone away
+			saveCtx := context.WithoutCancel(r.Context())
+			completed := false
+			defer func() {
+				if !completed {
+					_ = svc.Release(saveCtx, scope, key)
+				}
+			}()
+			next.ServeHTTP(rw, r)
+			if rw.status >= http.StatusInternalServerError {
+				return
+			}
+			if err := svc.Complete(saveCtx, scope, key, rw.status, rw.Header().Get("Content-Type"), rw.body.Bytes()); err == nil {
+				completed = true
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/audit"
+	"pvz-service/internal/usecase/ports"
+)
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+type ListParams struct {
+	PVZID   string
+	ActorID string
+	From    *time.Time
+	To      *time.Time
+	Limit   int
+}
+
+type Service struct {
+	repo ports.AuditRepository
+}
+
+func NewService(repo ports.AuditRepository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) List(ctx context.Context, params ListParams) ([]audit.Entry, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return s.repo.List(ctx, ports.AuditFilter{
+		PVZID:   params.PVZID,
+		ActorID: params.ActorID,
+		From:    params.From,
+		To:      params.To,
+		Limit:   limit,
+	})
+}
@@ -0,0 +1,15 @@
+package backoff
+
+import "time"
+
+// Exponential returns base*2^(attempt-1) capped at max.
+func Exponential(base, max time.Duration, attempt int) time.Duration {
+	d := base
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d
+}
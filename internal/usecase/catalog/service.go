@@ -0,0 +1,185 @@
+package catalog
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/usecase/ports"
+	"pvz-service/internal/usecase/snapshot"
+)
+
+const (
+	DefaultLocale   = "ru"
+	DefaultCacheTTL = 30 * time.Second
+)
+
+var codePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type CreateParams struct {
+	Code   string
+	Names  map[string]string
+	Active bool
+}
+
+type UpdateParams struct {
+	Names  map[string]string
+	Active *bool
+}
+
+// Service manages the product type catalog. Reads are served from an in-memory
+// snapshot that is refreshed after cacheTTL or immediately after any change made
+// through this service. Changes made by the other binary (HTTP or gRPC) show up
+// within cacheTTL.
+type Service struct {
+	repo   ports.ProductTypeRepository
+	clock  ports.Clock
+	byCode *snapshot.Cache[map[string]product.Type]
+}
+
+func NewService(repo ports.ProductTypeRepository, clock ports.Clock, cacheTTL time.Duration) *Service {
+	return &Service{
+		repo:  repo,
+		clock: clock,
+		byCode: snapshot.New(clock, cacheTTL, func(ctx context.Context) (map[string]product.Type, error) {
+			types, err := repo.List(ctx)
+			if err != nil {
+				return nil, err
+			}
+			byCode := make(map[string]product.Type, len(types))
+			for _, t := range types {
+				byCode[t.Code] = t
+			}
+			return byCode, nil
+		}),
+	}
+}
+
+func (s *Service) Invalidate() {
+	s.byCode.Invalidate()
+}
+
+func (s *Service) List(ctx context.Context) ([]product.Type, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *Service) Create(ctx context.Context, params CreateParams) (*product.Type, error) {
+	if !codePattern.MatchString(params.Code) {
+		return nil, product.ErrInvalidTypeCode
+	}
+	t := &product.Type{
+		Code:      params.Code,
+		Names:     normalizeNames(params.Names),
+		Active:    params.Active,
+		CreatedAt: s.clock.Now(),
+	}
+	if err := s.repo.Create(ctx, t); err != nil {
+		return nil, err
+	}
+	s.Invalidate()
+	return t, nil
+}
+
+func (s *Service) Update(ctx context.Context, code string, params UpdateParams) (*product.Type, error) {
+	types, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var current *product.Type
+	for i := range types {
+		if types[i].Code == code {
+			current = &types[i]
+			break
+		}
+	}
+	if current == nil {
+		return nil, product.ErrTypeNotFound
+	}
+	if params.Names != nil {
+		current.Names = normalizeNames(params.Names)
+	}
+	if params.Active != nil {
+		current.Active = *params.Active
+	}
+	if err := s.repo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+	s.Invalidate()
+	return current, nil
+}
+
+func (s *Service) Delete(ctx context.Context, code string) error {
+	if err := s.repo.Delete(ctx, code); err != nil {
+		return err
+	}
+	s.Invalidate()
+	return nil
+}
+
+func (s *Service) IsActive(ctx context.Context, code string) (bool, error) {
+	byCode, err := s.byCode.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	t, ok := byCode[code]
+	return ok && t.Active, nil
+}
+
+// Resolve maps a code or any localized name of an active type to its code.
+// A code wins over names; a name shared by several types is rejected.
+func (s *Service) Resolve(ctx context.Context, name string) (string, error) {
+	byCode, err := s.byCode.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+	name = strings.TrimSpace(name)
+	// codes are lower case, see codePattern
+	if t, ok := byCode[strings.ToLower(name)]; ok && t.Active {
+		return t.Code, nil
+	}
+	found := ""
+	for _, t := range byCode {
+		if !t.Active {
+			continue
+		}
+		for _, localized := range t.Names {
+			if !strings.EqualFold(localized, name) || found == t.Code {
+				continue
+			}
+			if found != "" {
+				return "", product.ErrAmbiguousType
+			}
+			found = t.Code
+		}
+	}
+	if found == "" {
+		return "", product.ErrInvalidType
+	}
+	return found, nil
+}
+
+func (s *Service) DisplayName(ctx context.Context, code, locale string) string {
+	byCode, err := s.byCode.Get(ctx)
+	if err != nil {
+		return code
+	}
+	t, ok := byCode[code]
+	if !ok {
+		return code
+	}
+	return t.Name(locale)
+}
+
+func normalizeNames(names map[string]string) map[string]string {
+	out := make(map[string]string, len(names))
+	for locale, name := range names {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		name = strings.TrimSpace(name)
+		if locale != "" && name != "" {
+			out[locale] = name
+		}
+	}
+	return out
+}
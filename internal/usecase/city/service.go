@@ -0,0 +1,179 @@
+package city
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/usecase/ports"
+	"pvz-service/internal/usecase/snapshot"
+)
+
+const (
+	DefaultTimezone = "Europe/Moscow"
+	DefaultCacheTTL = 30 * time.Second
+)
+
+type CreateParams struct {
+	Name     string
+	Aliases  map[string]string
+	Timezone string
+	Active   bool
+}
+
+type UpdateParams struct {
+	Aliases  map[string]string
+	Timezone *string
+	Active   *bool
+}
+
+// Service manages the city registry. Lookups are served from an in-memory
+// snapshot that is refreshed after cacheTTL or immediately after any change made
+// through this service. Changes made by the other binary (HTTP or gRPC) show up
+// within cacheTTL.
+type Service struct {
+	repo   ports.CityRepository
+	clock  ports.Clock
+	cities *snapshot.Cache[[]pvz.City]
+}
+
+func NewService(repo ports.CityRepository, clock ports.Clock, cacheTTL time.Duration) *Service {
+	return &Service{repo: repo, clock: clock, cities: snapshot.New(clock, cacheTTL, repo.List)}
+}
+
+func (s *Service) Invalidate() {
+	s.cities.Invalidate()
+}
+
+func (s *Service) List(ctx context.Context) ([]pvz.City, error) {
+	return s.repo.List(ctx)
+}
+
+// Resolve maps the canonical name or any alias of an active city to the city.
+// A canonical name wins over aliases; an alias shared by several cities is
+// rejected rather than resolved to whichever comes first.
+func (s *Service) Resolve(ctx context.Context, name string) (*pvz.City, error) {
+	cities, err := s.cities.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	for i := range cities {
+		if cities[i].Active && strings.EqualFold(cities[i].Name, name) {
+			c := cities[i]
+			return &c, nil
+		}
+	}
+	var found *pvz.City
+	for i := range cities {
+		c := cities[i]
+		if !c.Active || !c.Matches(name) {
+			continue
+		}
+		if found != nil {
+			return nil, pvz.ErrCityAmbiguous
+		}
+		found = &c
+	}
+	if found == nil {
+		return nil, pvz.ErrCityNotAllowed
+	}
+	return found, nil
+}
+
+// Canonical returns the canonical name for a city filter. Names that do not
+// resolve, e.g. of a deactivated city, are kept as given so that they still
+// match the PVZs already registered under them.
+func (s *Service) Canonical(ctx context.Context, name string) (string, error) {
+	c, err := s.Resolve(ctx, name)
+	switch {
+	case err == nil:
+		return c.Name, nil
+	case errors.Is(err, pvz.ErrCityNotAllowed):
+		return strings.TrimSpace(name), nil
+	default:
+		return "", err
+	}
+}
+
+func (s *Service) Create(ctx context.Context, params CreateParams) (*pvz.City, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return nil, pvz.ErrInvalidCityName
+	}
+	tz := params.Timezone
+	if tz == "" {
+		tz = DefaultTimezone
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return nil, pvz.ErrInvalidTimezone
+	}
+	c := &pvz.City{
+		Name:      name,
+		Aliases:   normalizeAliases(params.Aliases),
+		Timezone:  tz,
+		Active:    params.Active,
+		CreatedAt: s.clock.Now(),
+	}
+	if err := s.repo.Create(ctx, c); err != nil {
+		return nil, err
+	}
+	s.Invalidate()
+	return c, nil
+}
+
+func (s *Service) Update(ctx context.Context, name string, params UpdateParams) (*pvz.City, error) {
+	cities, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var current *pvz.City
+	for i := range cities {
+		if cities[i].Name == name {
+			current = &cities[i]
+			break
+		}
+	}
+	if current == nil {
+		return nil, pvz.ErrCityNotFound
+	}
+	if params.Aliases != nil {
+		current.Aliases = normalizeAliases(params.Aliases)
+	}
+	if params.Timezone != nil {
+		if _, err := time.LoadLocation(*params.Timezone); err != nil || *params.Timezone == "" {
+			return nil, pvz.ErrInvalidTimezone
+		}
+		current.Timezone = *params.Timezone
+	}
+	if params.Active != nil {
+		current.Active = *params.Active
+	}
+	if err := s.repo.Update(ctx, current); err != nil {
+		return nil, err
+	}
+	s.Invalidate()
+	return current, nil
+}
+
+func (s *Service) Delete(ctx context.Context, name string) error {
+	if err := s.repo.Delete(ctx, name); err != nil {
+		return err
+	}
+	s.Invalidate()
+	return nil
+}
+
+func normalizeAliases(aliases map[string]string) map[string]string {
+	out := make(map[string]string, len(aliases))
+	for locale, alias := range aliases {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		alias = strings.TrimSpace(alias)
+		if locale != "" && alias != "" {
+			out[locale] = alias
+		}
+	}
+	return out
+}
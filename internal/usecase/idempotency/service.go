@@ -0,0 +1,105 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"pvz-service/internal/domain/idempotency"
+	"pvz-service/internal/domain/user"
+	"pvz-service/internal/usecase/ports"
+)
+
+const (
+	DefaultTTL   = 24 * time.Hour
+	DefaultLease = time.Minute
+)
+
+// Service lets transports replay the stored outcome of a retried request.
+// The flow is Begin, then either Complete with the response or Release when
+// the request failed in a way worth retrying. A key left unfinished, e.g. by a
+// process that died mid-request, is held for lease and then taken over by the
+// next retry with the same payload.
+type Service struct {
+	repo  ports.IdempotencyRepository
+	clock ports.Clock
+	ttl   time.Duration
+	lease time.Duration
+}
+
+func NewService(repo ports.IdempotencyRepository, clock ports.Clock, ttl, lease time.Duration) *Service {
+	return &Service{repo: repo, clock: clock, ttl: ttl, lease: lease}
+}
+
+// Scope is the key namespace of the caller in ctx: keys of different users,
+// or of one user under different roles, never meet.
+func Scope(ctx context.Context) (string, error) {
+	u, ok := user.FromContext(ctx)
+	if !ok || u.ID == "" {
+		return "", idempotency.ErrAnonymousCaller
+	}
+	return u.Role + ":" + u.ID, nil
+}
+
+// Fingerprint identifies a request by its target and payload.
+func Fingerprint(parts ...[]byte) string {
+	h := sha256.New()
+	for _, p := range parts {
+		_, _ = h.Write(p)
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Begin reserves the key. It returns nil when the caller should execute the
+// request, or the completed record to replay.
+func (s *Service) Begin(ctx context.Context, scope, key, fingerprint string) (*idempotency.Record, error) {
+	if !idempotency.ValidKey(key) {
+		return nil, idempotency.ErrInvalidKey
+	}
+	now := s.clock.Now()
+	existing, err := s.repo.Reserve(ctx, idempotency.Record{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+		LockedUntil: now.Add(s.lease),
+	})
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	if existing.Fingerprint != fingerprint {
+		return nil, idempotency.ErrKeyReused
+	}
+	if !existing.Completed {
+		return nil, idempotency.ErrRequestInProgress
+	}
+	return existing, nil
+}
+
+// Complete stores the response. It is not atomic with the business
+// transaction: if the process dies after the commit but before Complete, the
+// lease runs out and a retry executes the request a second time.
+func (s *Service) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error {
+	return s.repo.Complete(ctx, scope, key, statusCode, contentType, body)
+}
+
+func (s *Service) Release(ctx context.Context, scope, key string) error {
+	return s.repo.Release(ctx, scope, key)
+}
+
+// Run purges expired keys until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, _ = s.repo.DeleteExpired(ctx, s.clock.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
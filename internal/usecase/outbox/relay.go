@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/usecase/backoff"
+	"pvz-service/internal/usecase/ports"
+)
+
+type Config struct {
+	PollInterval time.Duration
+	// Lease is how long a claimed message stays hidden from other relays; it
+	// has to outlast publishing a whole batch.
+	Lease       time.Duration
+	BatchSize   int
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Relay delivers outbox messages to the publisher with at-least-once semantics:
+// a message is marked delivered only after Publish succeeds, failed messages are
+// retried with exponential backoff and moved to the dead state after MaxAttempts.
+// Messages whose payload cannot be decoded are dead on the first attempt.
+//
+// No transaction is held while publishing: messages are claimed for Lease and
+// each outcome is stored on its own, so a relay that dies mid-batch only
+// causes the unfinished messages to be published again once the lease ends.
+type Relay struct {
+	repo      ports.OutboxRepository
+	publisher ports.OutboxPublisher
+	clock     ports.Clock
+	cfg       Config
+}
+
+func NewRelay(repo ports.OutboxRepository, publisher ports.OutboxPublisher, clock ports.Clock, cfg Config) *Relay {
+	return &Relay{repo: repo, publisher: publisher, clock: clock, cfg: cfg}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := r.ProcessBatch(ctx)
+			if err != nil || n < r.cfg.BatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	now := r.clock.Now()
+	msgs, err := r.repo.ClaimPending(ctx, now, now.Add(r.cfg.Lease), r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, msg := range msgs {
+		if err := r.deliver(ctx, msg); err != nil {
+			return 0, err
+		}
+	}
+	return len(msgs), nil
+}
+
+func (r *Relay) deliver(ctx context.Context, msg ports.OutboxMessage) error {
+	if msg.DecodeErr != nil {
+		return r.repo.MarkFailed(ctx, msg.ID, msg.Attempts+1, r.clock.Now(), msg.DecodeErr.Error(), true)
+	}
+	pubErr := r.publisher.Publish(ctx, msg.Event)
+	if pubErr == nil {
+		return r.repo.MarkDelivered(ctx, msg.ID, r.clock.Now())
+	}
+	attempts := msg.Attempts + 1
+	dead := attempts >= r.cfg.MaxAttempts
+	next := r.clock.Now().Add(backoff.Exponential(r.cfg.BaseBackoff, r.cfg.MaxBackoff, attempts))
+	return r.repo.MarkFailed(ctx, msg.ID, attempts, next, pubErr.Error(), dead)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/audit"
+)
+
+type AuditFilter struct {
+	PVZID   string
+	ActorID string
+	From    *time.Time
+	To      *time.Time
+	Limit   int
+}
+
+type AuditRepository interface {
+	Append(ctx context.Context, e audit.Entry) error
+	AppendMany(ctx context.Context, entries []audit.Entry) error
+	List(ctx context.Context, filter AuditFilter) ([]audit.Entry, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"pvz-service/internal/domain/product"
+)
+
+type ProductTypeRepository interface {
+	List(ctx context.Context) ([]product.Type, error)
+	Create(ctx context.Context, t *product.Type) error
+	Update(ctx context.Context, t *product.Type) error
+	Delete(ctx context.Context, code string) error
+}
+
+type ProductCatalog interface {
+	IsActive(ctx context.Context, code string) (bool, error)
+}
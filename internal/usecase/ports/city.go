@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"pvz-service/internal/domain/pvz"
+)
+
+type CityRepository interface {
+	List(ctx context.Context) ([]pvz.City, error)
+	Create(ctx context.Context, c *pvz.City) error
+	Update(ctx context.Context, c *pvz.City) error
+	Delete(ctx context.Context, name string) error
+}
+
+type CityRegistry interface {
+	Resolve(ctx context.Context, name string) (*pvz.City, error)
+	Canonical(ctx context.Context, name string) (string, error)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+	"errors"
+
+	"pvz-service/internal/domain/event"
+)
+
+var ErrSequenceExpired = errors.New("события после указанной позиции больше недоступны")
+
+type SequencedEvent struct {
+	Sequence uint64
+	Event    event.DomainEvent
+}
+
+// EventLog is the committed, ordered history of domain events.
+type EventLog interface {
+	ListAfter(ctx context.Context, afterSequence uint64, limit int) ([]OutboxMessage, error)
+	LastSequence(ctx context.Context) (uint64, error)
+}
+
+type EventSubscriber interface {
+	Subscribe(ctx context.Context, afterSequence uint64, filter func(event.DomainEvent) bool) (<-chan SequencedEvent, error)
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/idempotency"
+)
+
+type IdempotencyRepository interface {
+	// Reserve stores rec unless a live record with the same scope and key
+	// exists; in that case the existing record is returned instead.
+	Reserve(ctx context.Context, rec idempotency.Record) (*idempotency.Record, error)
+	Complete(ctx context.Context, scope, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
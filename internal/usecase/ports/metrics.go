@@ -8,4 +8,5 @@ type Metrics interface {
 	IncPVZCreated()
 	IncReceptionCreated()
 	IncProductAdded()
+	IncStaleReception(action string)
 }
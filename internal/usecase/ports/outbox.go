@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/event"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusDelivered = "delivered"
+	OutboxStatusDead      = "dead"
+)
+
+type OutboxMessage struct {
+	ID       string
+	Sequence uint64
+	Event    event.DomainEvent
+	Attempts int
+	// DecodeErr is set when the stored payload could not be read back; Event
+	// is empty then.
+	DecodeErr error
+}
+
+type OutboxRepository interface {
+	Add(ctx context.Context, e event.DomainEvent) error
+	AddMany(ctx context.Context, events []event.DomainEvent) error
+	// ClaimPending takes up to limit due messages and postpones them to
+	// leaseUntil, so that other relays skip them while they are published.
+	ClaimPending(ctx context.Context, now, leaseUntil time.Time, limit int) ([]OutboxMessage, error)
+	// ListAfter returns messages of any status in sequence order.
+	ListAfter(ctx context.Context, afterSequence uint64, limit int) ([]OutboxMessage, error)
+	LastSequence(ctx context.Context) (uint64, error)
+	MarkDelivered(ctx context.Context, id string, at time.Time) error
+	MarkFailed(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error
+}
+
+type OutboxPublisher interface {
+	Publish(ctx context.Context, e event.DomainEvent) error
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+)
+
+type PVZTree struct {
+	PVZ        pvz.PVZ
+	Receptions []ReceptionTree
+}
+
+// ReceptionTree carries a reception with its listed products; the
+// ProductCount of an open reception is the live one.
+type ReceptionTree struct {
+	Reception reception.Reception
+	Products  []product.Product
+}
+
+type ProductLocation struct {
+	Product   product.Product
+	Reception reception.Reception
+	PVZ       pvz.PVZ
+}
+
+type PVZReadModel interface {
+	ListTrees(ctx context.Context, filter PVZListFilter) ([]PVZTree, error)
+	FindProductsByBarcode(ctx context.Context, barcode string) ([]ProductLocation, error)
+}
@@ -17,19 +17,127 @@ type UserRepository interface {
 type PVZRepository interface {
 	Create(ctx context.Context, p *pvz.PVZ) error
 	Get(ctx context.Context, id string) (*pvz.PVZ, error)
-	List(ctx context.Context, from, to *time.Time, limit, offset int) ([]pvz.PVZ, error)
+	GetForUpdate(ctx context.Context, id string) (*pvz.PVZ, error)
+	GetCapacity(ctx context.Context, pvzID string) (pvz.Capacity, error)
+	SetCapacity(ctx context.Context, pvzID string, c pvz.Capacity) error
+	Update(ctx context.Context, p *pvz.PVZ) error
+	Nearest(ctx context.Context, q NearestPVZQuery) ([]PVZDistance, error)
+}
+
+// NearestPVZQuery looks for active PVZs around From; RadiusKm of zero means
+// no distance limit.
+type NearestPVZQuery struct {
+	From     pvz.Coordinates
+	RadiusKm float64
+	Limit    int
+}
+
+type PVZDistance struct {
+	PVZ        pvz.PVZ
+	DistanceKm float64
+}
+
+type PVZCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// DateField selects what the date bounds of PVZListFilter apply to.
+type DateField string
+
+const (
+	// DateFieldReception keeps receptions started within the bounds, with
+	// all their products.
+	DateFieldReception DateField = "reception"
+	// DateFieldProduct keeps products added within the bounds and the
+	// receptions holding at least one of them.
+	DateFieldProduct DateField = "product"
+	// DateFieldPVZ keeps PVZs registered within the bounds, with all their
+	// receptions.
+	DateFieldPVZ DateField = "pvz"
+)
+
+// PVZListFilter bounds receptions by absolute instants (From/To) and/or by
+// wall-clock times in each PVZ's own timezone (LocalFrom/LocalTo).
+type PVZListFilter struct {
+	From       *time.Time
+	To         *time.Time
+	LocalFrom  *time.Time
+	LocalTo    *time.Time
+	ClosedFrom *time.Time
+	ClosedTo   *time.Time
+	OpenedBy   string
+	ClosedBy   string
+	Limit      int
+	Offset     int
+	After      *PVZCursor
+
+	// IncludeDecommissioned lists decommissioned PVZs too; they are hidden
+	// by default.
+	IncludeDecommissioned bool
+
+	// City matches the canonical city name, case-insensitively.
+	City string
+	// HasOpenReception keeps only PVZs with (true) or without (false) an
+	// in-progress or reopened reception.
+	HasOpenReception *bool
+
+	// The reception filters below combine with the ones above: a PVZ is
+	// listed when at least one of its receptions matches them all.
+	ReceptionStatuses []string
+	// ProductType keeps receptions holding at least one product of the type.
+	ProductType string
+	MinProducts *int
+	MaxProducts *int
+
+	// DateField is what From/To/LocalFrom/LocalTo apply to; empty means
+	// DateFieldReception.
+	DateField DateField
+	// IncludeEmpty lists PVZs even when none of their receptions match the
+	// reception filters; such PVZs come with no receptions.
+	IncludeEmpty bool
+}
+
+func (f PVZListFilter) HasDateBounds() bool {
+	return f.From != nil || f.To != nil || f.LocalFrom != nil || f.LocalTo != nil
+}
+
+func (f PVZListFilter) HasReceptionFilter() bool {
+	return (f.HasDateBounds() && f.DateField != DateFieldPVZ) ||
+		f.ClosedFrom != nil || f.ClosedTo != nil || f.OpenedBy != "" || f.ClosedBy != "" ||
+		len(f.ReceptionStatuses) > 0 || f.ProductType != "" || f.MinProducts != nil || f.MaxProducts != nil
+}
+
+// StaleReceptionQuery selects in-progress receptions opened longer ago than
+// their PVZ's threshold, or DefaultMaxAge when the PVZ has none.
+type StaleReceptionQuery struct {
+	Now           time.Time
+	DefaultMaxAge time.Duration
+	UnflaggedOnly bool
+	Limit         int
 }
 
 type ReceptionRepository interface {
 	Create(ctx context.Context, r *reception.Reception) error
+	Get(ctx context.Context, id string) (*reception.Reception, error)
 	GetOpenByPVZ(ctx context.Context, pvzID string) (*reception.Reception, error)
-	Close(ctx context.Context, receptionID string) error
-	GetByPVZ(ctx context.Context, pvzID string, from, to *time.Time) ([]reception.Reception, error)
+	UpdateStatus(ctx context.Context, r *reception.Reception) error
+	ListStale(ctx context.Context, q StaleReceptionQuery) ([]reception.Reception, error)
+	// MarkStale returns how many receptions it flagged: 0 if already flagged.
+	MarkStale(ctx context.Context, receptionID string, at time.Time) (int64, error)
+	Delete(ctx context.Context, receptionID, deletedBy string, at time.Time) error
+	// GetByPVZ applies only the reception filters of filter.
+	GetByPVZ(ctx context.Context, pvzID string, filter PVZListFilter) ([]reception.Reception, error)
 }
 
 type ProductRepository interface {
 	Create(ctx context.Context, pr *product.Product) error
+	CreateMany(ctx context.Context, products []*product.Product) error
+	Get(ctx context.Context, id string) (*product.Product, error)
 	GetLastByReception(ctx context.Context, receptionID string) (*product.Product, error)
-	Delete(ctx context.Context, productID string) error
-	GetByReception(ctx context.Context, receptionID string) ([]product.Product, error)
+	CountByReception(ctx context.Context, receptionID string) (int, error)
+	CountByReceptionAndType(ctx context.Context, receptionID, productType string) (int, error)
+	CountByType(ctx context.Context, receptionID string) (map[string]int, error)
+	ExistingBarcodes(ctx context.Context, receptionID string, barcodes []string) (map[string]bool, error)
+	Delete(ctx context.Context, productID, deletedBy string, at time.Time) error
 }
@@ -7,6 +7,9 @@ type Tx interface {
 	PVZRepo() PVZRepository
 	ReceptionRepo() ReceptionRepository
 	ProductRepo() ProductRepository
+	OutboxRepo() OutboxRepository
+	WebhookRepo() WebhookRepository
+	AuditRepo() AuditRepository
 	Commit() error
 	Rollback() error
 }
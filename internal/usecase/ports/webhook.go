@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/webhook"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, s *webhook.Subscription) error
+	GetSubscription(ctx context.Context, id string) (*webhook.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]webhook.Subscription, error)
+	ListActiveSubscriptions(ctx context.Context) ([]webhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id string) (bool, error)
+	RecordSuccess(ctx context.Context, subscriptionID string) error
+	RecordFailure(ctx context.Context, subscriptionID string, maxFailures int, at time.Time) error
+
+	CreateDelivery(ctx context.Context, d *webhook.Delivery) error
+	// ClaimPendingDeliveries takes up to limit due deliveries of active
+	// subscriptions and postpones them to leaseUntil while they are sent.
+	ClaimPendingDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]webhook.Delivery, error)
+	MarkDelivered(ctx context.Context, id string, statusCode int, at time.Time) error
+	MarkAttemptFailed(ctx context.Context, id string, attempts int, nextAttemptAt time.Time, statusCode int, lastError string, failed bool) error
+	ListDeliveries(ctx context.Context, subscriptionID string, limit int) ([]webhook.Delivery, error)
+}
+
+type WebhookSender interface {
+	Send(ctx context.Context, url, secret string, d *webhook.Delivery) (int, error)
+}
@@ -0,0 +1,68 @@
+package pvz
+
+import (
+	"context"
+	"strconv"
+
+	"pvz-service/internal/domain/audit"
+	"pvz-service/internal/domain/pvz"
+
+	"github.com/google/uuid"
+)
+
+func (s *Service) GetCapacity(ctx context.Context, pvzID string) (pvz.Capacity, error) {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return pvz.Capacity{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	return tx.PVZRepo().GetCapacity(ctx, pvzID)
+}
+
+// SetCapacity replaces the PVZ limits. Products already received are kept
+// even if they exceed the new limits.
+func (s *Service) SetCapacity(ctx context.Context, pvzID string, c pvz.Capacity) (pvz.Capacity, error) {
+	if err := c.Validate(); err != nil {
+		return pvz.Capacity{}, err
+	}
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return pvz.Capacity{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	p, err := tx.PVZRepo().GetForUpdate(ctx, pvzID)
+	if err != nil {
+		return pvz.Capacity{}, err
+	}
+	if p == nil {
+		return pvz.Capacity{}, pvz.ErrNotFound
+	}
+	if err := tx.PVZRepo().SetCapacity(ctx, pvzID, c); err != nil {
+		return pvz.Capacity{}, err
+	}
+
+	actorID, actorRole := audit.Actor(ctx)
+	details := map[string]string{"maxProducts": strconv.Itoa(c.MaxProducts)}
+	for code, n := range c.MaxPerType {
+		details["maxPerType."+code] = strconv.Itoa(n)
+	}
+	if err := tx.AuditRepo().Append(ctx, audit.Entry{
+		ID:         uuid.New().String(),
+		OccurredAt: s.clock.Now(),
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Action:     audit.ActionPVZCapacityUpdated,
+		PVZID:      pvzID,
+		Details:    details,
+	}); err != nil {
+		return pvz.Capacity{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pvz.Capacity{}, err
+	}
+	if c.MaxPerType == nil {
+		c.MaxPerType = map[string]int{}
+	}
+	return c, nil
+}
@@ -0,0 +1,35 @@
+package pvz
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/google/uuid"
+)
+
+func encodeCursor(c ports.PVZCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(s string) (*ports.PVZCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &ports.PVZCursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -0,0 +1,177 @@
+package pvz
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"pvz-service/internal/domain/audit"
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/google/uuid"
+)
+
+// Get returns the PVZ, decommissioned or not, with its receptions matching
+// params; the counts cover the returned receptions only.
+func (s *Service) Get(ctx context.Context, pvzID string, params DetailsParams) (*PVZDetails, error) {
+	filter := ports.PVZListFilter{ReceptionStatuses: params.ReceptionStatuses, ProductType: params.ProductType}
+	if err := validateListFilter(filter); err != nil {
+		return nil, err
+	}
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	p, err := tx.PVZRepo().Get(ctx, pvzID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, pvz.ErrNotFound
+	}
+	recs, err := tx.ReceptionRepo().GetByPVZ(ctx, pvzID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &PVZDetails{PVZInfo: toPVZInfo(*p), ReceptionCount: len(recs)}
+	details.Receptions = make([]ReceptionInfo, 0, len(recs))
+	for _, rec := range recs {
+		info := toReceptionInfo(rec)
+		if reception.IsOpen(rec.Status) {
+			// the stored total is only settled on close
+			if info.ProductCount, err = tx.ProductRepo().CountByReception(ctx, rec.ID); err != nil {
+				return nil, err
+			}
+		}
+		if rec.Status != reception.StatusCancelled {
+			details.ProductCount += info.ProductCount
+		}
+		details.Receptions = append(details.Receptions, info)
+	}
+	return details, nil
+}
+
+func (s *Service) Update(ctx context.Context, pvzID string, params UpdateParams) (*PVZInfo, error) {
+	if params.Name != nil && !pvz.ValidName(*params.Name) {
+		return nil, pvz.ErrInvalidName
+	}
+	if params.Address != nil && !pvz.ValidAddress(*params.Address) {
+		return nil, pvz.ErrInvalidAddress
+	}
+	if params.WorkingHours != nil {
+		if err := params.WorkingHours.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if params.Coordinates != nil {
+		if err := params.Coordinates.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	details := map[string]string{}
+	p, err := s.modify(ctx, pvzID, audit.ActionPVZUpdated, details, func(_ ports.Tx, p *pvz.PVZ, _ time.Time) error {
+		if !p.Active() {
+			return pvz.ErrDecommissioned
+		}
+		if params.Name != nil {
+			p.Name = strings.TrimSpace(*params.Name)
+			details["name"] = p.Name
+		}
+		if params.Address != nil {
+			p.Address = strings.TrimSpace(*params.Address)
+			details["address"] = p.Address
+		}
+		if params.WorkingHours != nil {
+			p.WorkingHours = *params.WorkingHours
+			for _, day := range pvz.Weekdays {
+				if h, ok := p.WorkingHours[day]; ok {
+					details["workingHours."+day] = h.Open + "-" + h.Close
+				}
+			}
+		}
+		if params.Coordinates != nil {
+			p.Coordinates = params.Coordinates
+			details["latitude"] = strconv.FormatFloat(p.Coordinates.Latitude, 'f', -1, 64)
+			details["longitude"] = strconv.FormatFloat(p.Coordinates.Longitude, 'f', -1, 64)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	info := toPVZInfo(*p)
+	return &info, nil
+}
+
+// Decommission takes the PVZ out of service: it no longer accepts receptions
+// and is hidden from listings unless asked for. The open reception, if any,
+// has to be closed or cancelled first.
+func (s *Service) Decommission(ctx context.Context, pvzID string) (*PVZInfo, error) {
+	p, err := s.modify(ctx, pvzID, audit.ActionPVZDecommissioned, nil, func(tx ports.Tx, p *pvz.PVZ, now time.Time) error {
+		if !p.Active() {
+			return pvz.ErrDecommissioned
+		}
+		open, err := tx.ReceptionRepo().GetOpenByPVZ(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if open != nil {
+			return pvz.ErrHasOpenReception
+		}
+		p.DecommissionedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	info := toPVZInfo(*p)
+	return &info, nil
+}
+
+// modify applies change to the locked PVZ and stores it with an audit entry.
+func (s *Service) modify(ctx context.Context, pvzID string, action audit.Action, details map[string]string, change func(ports.Tx, *pvz.PVZ, time.Time) error) (*pvz.PVZ, error) {
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	p, err := tx.PVZRepo().GetForUpdate(ctx, pvzID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, pvz.ErrNotFound
+	}
+	now := s.clock.Now()
+	if err := change(tx, p, now); err != nil {
+		return nil, err
+	}
+	if err := tx.PVZRepo().Update(ctx, p); err != nil {
+		return nil, err
+	}
+
+	actorID, actorRole := audit.Actor(ctx)
+	if err := tx.AuditRepo().Append(ctx, audit.Entry{
+		ID:         uuid.New().String(),
+		OccurredAt: now,
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		Action:     action,
+		PVZID:      pvzID,
+		Details:    details,
+	}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
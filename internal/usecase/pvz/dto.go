@@ -1,23 +1,134 @@
 package pvz
 
-import "time"
+import (
+	"time"
+
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+)
 
 type PVZInfo struct {
-	ID         string
-	City       string
-	CreatedAt  time.Time
-	Receptions []ReceptionInfo
+	ID                  string
+	City                string
+	Timezone            string
+	CreatedAt           time.Time
+	StaleReceptionAfter time.Duration
+	Receptions          []ReceptionInfo
+
+	Name             string
+	Address          string
+	WorkingHours     pvz.Schedule
+	DecommissionedAt *time.Time
+	Coordinates      *pvz.Coordinates
+}
+
+// PVZDetails lists all receptions of the PVZ without their products.
+type PVZDetails struct {
+	PVZInfo
+	ReceptionCount int
+	ProductCount   int
 }
 
+type DetailsParams struct {
+	ReceptionStatuses []string
+	ProductType       string
+}
+
+// UpdateParams changes only the fields that are set.
+type UpdateParams struct {
+	Name         *string
+	Address      *string
+	WorkingHours *pvz.Schedule
+	Coordinates  *pvz.Coordinates
+}
+
+// ReceptionInfo carries a reception with the products listed for it.
+// ProductCount counts all its products, listed or not.
 type ReceptionInfo struct {
-	ID        string
-	StartedAt time.Time
-	Status    string
-	Products  []ProductInfo
+	ID           string
+	StartedAt    time.Time
+	Status       string
+	ClosedAt     *time.Time
+	OpenedBy     string
+	ClosedBy     string
+	ProductCount int
+	Products     []ProductInfo
 }
 
 type ProductInfo struct {
 	ID      string
 	AddedAt time.Time
 	Type    string
+
+	Barcode              string
+	SKU                  string
+	WeightGrams          int
+	DeclaredValueKopecks int64
+}
+
+type ProductLocationInfo struct {
+	Product   ProductInfo
+	Reception reception.Reception
+	City      string
+	Timezone  string
+}
+
+type CreateParams struct {
+	City     string
+	Timezone string
+	// StaleReceptionAfter is optional; zero falls back to the global setting.
+	StaleReceptionAfter time.Duration
+
+	// The details below are optional but validated when given.
+	Name         string
+	Address      string
+	WorkingHours pvz.Schedule
+	Coordinates  *pvz.Coordinates
+}
+
+type NearestParams struct {
+	From     pvz.Coordinates
+	RadiusKm float64
+	Limit    int
+}
+
+type NearbyPVZ struct {
+	PVZInfo
+	DistanceKm float64
+}
+
+type ListParams struct {
+	From      *time.Time
+	To        *time.Time
+	LocalFrom *time.Time
+	LocalTo   *time.Time
+	Limit     int
+	Offset    int
+	Cursor    string
+
+	ClosedFrom *time.Time
+	ClosedTo   *time.Time
+	OpenedBy   string
+	ClosedBy   string
+
+	IncludeDecommissioned bool
+
+	// City accepts any alias known to the city registry.
+	City              string
+	HasOpenReception  *bool
+	ReceptionStatuses []string
+	ProductType       string
+	MinProducts       *int
+	MaxProducts       *int
+
+	// DateField is what From/To/LocalFrom/LocalTo apply to: "reception"
+	// (the default), "product" or "pvz".
+	DateField string
+	// IncludeEmpty keeps PVZs that have no receptions matching the filters.
+	IncludeEmpty bool
+}
+
+type ListResult struct {
+	Items      []PVZInfo
+	NextCursor string
 }
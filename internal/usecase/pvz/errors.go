@@ -0,0 +1,9 @@
+package pvz
+
+import "errors"
+
+var (
+	ErrInvalidCursor = errors.New("некорректный курсор")
+	ErrInvalidRadius = errors.New("некорректный радиус поиска")
+	ErrInvalidFilter = errors.New("некорректный фильтр")
+)
@@ -0,0 +1,50 @@
+package pvz
+
+import (
+	"context"
+
+	"pvz-service/internal/usecase/ports"
+)
+
+const (
+	DefaultNearestLimit = 10
+	MaxNearestLimit     = 50
+)
+
+// Nearest lists active PVZs with known coordinates by distance from
+// params.From.
+func (s *Service) Nearest(ctx context.Context, params NearestParams) ([]NearbyPVZ, error) {
+	if err := params.From.Validate(); err != nil {
+		return nil, err
+	}
+	if params.RadiusKm < 0 {
+		return nil, ErrInvalidRadius
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultNearestLimit
+	}
+	if limit > MaxNearestLimit {
+		limit = MaxNearestLimit
+	}
+
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	found, err := tx.PVZRepo().Nearest(ctx, ports.NearestPVZQuery{
+		From:     params.From,
+		RadiusKm: params.RadiusKm,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]NearbyPVZ, 0, len(found))
+	for _, f := range found {
+		result = append(result, NearbyPVZ{PVZInfo: toPVZInfo(f.PVZ), DistanceKm: f.DistanceKm})
+	}
+	return result, nil
+}
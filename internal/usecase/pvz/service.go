@@ -5,109 +5,260 @@ import (
 	"strings"
 	"time"
 
+	"pvz-service/internal/domain/audit"
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/domain/product"
 	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
 	"pvz-service/internal/usecase/ports"
 
 	"github.com/google/uuid"
 )
 
 type Service struct {
-	pvzRepo       ports.PVZRepository
-	receptionRepo ports.ReceptionRepository
-	productRepo   ports.ProductRepository
-	clock         ports.Clock
+	txManager ports.TxManager
+	readModel ports.PVZReadModel
+	cities    ports.CityRegistry
+	clock     ports.Clock
 }
 
-func NewService(pvzRepo ports.PVZRepository, receptionRepo ports.ReceptionRepository, productRepo ports.ProductRepository, clock ports.Clock) *Service {
-	return &Service{pvzRepo: pvzRepo, receptionRepo: receptionRepo, productRepo: productRepo, clock: clock}
+func NewService(txManager ports.TxManager, readModel ports.PVZReadModel, cities ports.CityRegistry, clock ports.Clock) *Service {
+	return &Service{txManager: txManager, readModel: readModel, cities: cities, clock: clock}
 }
 
-func (s *Service) Create(ctx context.Context, city string) (*PVZInfo, error) {
-	normalizedCity, ok := normalizeCity(city)
-	if !ok {
-		return nil, pvz.ErrCityNotAllowed
+func (s *Service) Create(ctx context.Context, params CreateParams) (*PVZInfo, error) {
+	if params.StaleReceptionAfter < 0 || params.StaleReceptionAfter%time.Second != 0 {
+		return nil, pvz.ErrInvalidStaleAge
+	}
+	if err := validateDetails(params); err != nil {
+		return nil, err
+	}
+	resolved, err := s.cities.Resolve(ctx, params.City)
+	if err != nil {
+		return nil, err
+	}
+	timezone := resolved.Timezone
+	if params.Timezone != "" {
+		if _, err := time.LoadLocation(params.Timezone); err != nil {
+			return nil, pvz.ErrInvalidTimezone
+		}
+		timezone = params.Timezone
 	}
 	id := uuid.New().String()
 	p := &pvz.PVZ{
 		ID:        id,
-		City:      normalizedCity,
+		City:      resolved.Name,
+		Timezone:  timezone,
 		CreatedAt: s.clock.Now(),
+
+		StaleReceptionAfter: params.StaleReceptionAfter,
+
+		Name:         strings.TrimSpace(params.Name),
+		Address:      strings.TrimSpace(params.Address),
+		WorkingHours: params.WorkingHours,
+		Coordinates:  params.Coordinates,
+	}
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if err := s.pvzRepo.Create(ctx, p); err != nil {
+	defer func() { _ = tx.Rollback() }()
+	if err := tx.PVZRepo().Create(ctx, p); err != nil {
 		return nil, err
 	}
-	return &PVZInfo{
-		ID:         p.ID,
+	evt := event.DomainEvent{
+		ID:         uuid.New().String(),
+		Type:       event.PVZCreated,
+		PVZID:      p.ID,
 		City:       p.City,
-		CreatedAt:  p.CreatedAt,
-		Receptions: []ReceptionInfo{},
-	}, nil
+		OccurredAt: p.CreatedAt,
+	}
+	if err := tx.OutboxRepo().Add(ctx, evt); err != nil {
+		return nil, err
+	}
+	if err := tx.AuditRepo().Append(ctx, audit.ForEvent(ctx, uuid.New().String(), evt)); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	info := toPVZInfo(*p)
+	info.Receptions = []ReceptionInfo{}
+	return &info, nil
 }
 
-func (s *Service) List(ctx context.Context, from, to *time.Time, limit, offset int) ([]PVZInfo, error) {
-	pvzList, err := s.pvzRepo.List(ctx, from, to, limit, offset)
-	if err != nil {
+func validateDetails(params CreateParams) error {
+	if params.Name != "" && !pvz.ValidName(params.Name) {
+		return pvz.ErrInvalidName
+	}
+	if params.Address != "" && !pvz.ValidAddress(params.Address) {
+		return pvz.ErrInvalidAddress
+	}
+	if err := params.WorkingHours.Validate(); err != nil {
+		return err
+	}
+	if params.Coordinates != nil {
+		return params.Coordinates.Validate()
+	}
+	return nil
+}
+
+// CanonicalCity resolves a city filter the way List does.
+func (s *Service) CanonicalCity(ctx context.Context, name string) (string, error) {
+	return s.cities.Canonical(ctx, name)
+}
+
+// List returns the matching PVZs with their receptions. A product-date filter
+// narrows the listed products, never a reception's ProductCount.
+func (s *Service) List(ctx context.Context, params ListParams) (*ListResult, error) {
+	filter := ports.PVZListFilter{
+		From:       params.From,
+		To:         params.To,
+		LocalFrom:  params.LocalFrom,
+		LocalTo:    params.LocalTo,
+		ClosedFrom: params.ClosedFrom,
+		ClosedTo:   params.ClosedTo,
+		OpenedBy:   params.OpenedBy,
+		ClosedBy:   params.ClosedBy,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+
+		IncludeDecommissioned: params.IncludeDecommissioned,
+
+		HasOpenReception:  params.HasOpenReception,
+		ReceptionStatuses: params.ReceptionStatuses,
+		ProductType:       params.ProductType,
+		MinProducts:       params.MinProducts,
+		MaxProducts:       params.MaxProducts,
+
+		DateField:    ports.DateField(params.DateField),
+		IncludeEmpty: params.IncludeEmpty,
+	}
+	if err := validateListFilter(filter); err != nil {
 		return nil, err
 	}
-	var result []PVZInfo
-	for _, p := range pvzList {
-		riList, err := s.receptionRepo.GetByPVZ(ctx, p.ID, from, to)
+	if params.City != "" {
+		city, err := s.cities.Canonical(ctx, params.City)
+		if err != nil {
+			return nil, err
+		}
+		filter.City = city
+	}
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor)
 		if err != nil {
 			return nil, err
 		}
+		filter.After = after
+	}
+	trees, err := s.readModel.ListTrees(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	var result []PVZInfo
+	for _, t := range trees {
 		var recvInfos []ReceptionInfo
-		for _, r := range riList {
-			piList, err := s.productRepo.GetByReception(ctx, r.ID)
-			if err != nil {
-				return nil, err
-			}
+		for _, rt := range t.Receptions {
 			var prodInfos []ProductInfo
-			for _, pr := range piList {
-				prodInfos = append(prodInfos, ProductInfo{
-					ID:      pr.ID,
-					AddedAt: pr.AddedAt,
-					Type:    pr.Type,
-				})
+			for _, pr := range rt.Products {
+				prodInfos = append(prodInfos, toProductInfo(pr))
 			}
-			recvInfos = append(recvInfos, ReceptionInfo{
-				ID:        r.ID,
-				StartedAt: r.StartedAt,
-				Status:    r.Status,
-				Products:  prodInfos,
-			})
+			info := toReceptionInfo(rt.Reception)
+			info.Products = prodInfos
+			recvInfos = append(recvInfos, info)
 		}
-		result = append(result, PVZInfo{
-			ID:         p.ID,
-			City:       p.City,
-			CreatedAt:  p.CreatedAt,
-			Receptions: recvInfos,
+		info := toPVZInfo(t.PVZ)
+		info.Receptions = recvInfos
+		result = append(result, info)
+	}
+	res := &ListResult{Items: result}
+	if params.Limit > 0 && len(trees) == params.Limit {
+		last := trees[len(trees)-1].PVZ
+		res.NextCursor = encodeCursor(ports.PVZCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	return res, nil
+}
+
+func validateListFilter(f ports.PVZListFilter) error {
+	switch f.DateField {
+	case "", ports.DateFieldReception, ports.DateFieldProduct, ports.DateFieldPVZ:
+	default:
+		return ErrInvalidFilter
+	}
+	for _, st := range f.ReceptionStatuses {
+		if !reception.ValidStatus(st) {
+			return ErrInvalidFilter
+		}
+	}
+	if (f.MinProducts != nil && *f.MinProducts < 0) || (f.MaxProducts != nil && *f.MaxProducts < 0) {
+		return ErrInvalidFilter
+	}
+	if f.MinProducts != nil && f.MaxProducts != nil && *f.MinProducts > *f.MaxProducts {
+		return ErrInvalidFilter
+	}
+	return nil
+}
+
+// LocateProduct returns every reception that holds a product with the barcode,
+// most recent first.
+func (s *Service) LocateProduct(ctx context.Context, barcode string) ([]ProductLocationInfo, error) {
+	if !product.ValidBarcode(barcode) {
+		return nil, product.ErrInvalidBarcode
+	}
+	locations, err := s.readModel.FindProductsByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, product.ErrNotFound
+	}
+	result := make([]ProductLocationInfo, 0, len(locations))
+	for _, l := range locations {
+		result = append(result, ProductLocationInfo{
+			Product:   toProductInfo(l.Product),
+			Reception: l.Reception,
+			City:      l.PVZ.City,
+			Timezone:  l.PVZ.Timezone,
 		})
 	}
 	return result, nil
 }
 
-func pvzCityEnglish(city string) string {
-	switch city {
-	case "Москва":
-		return "Moscow"
-	case "Санкт-Петербург":
-		return "Saint Petersburg"
-	case "Казань":
-		return "Kazan"
+func toPVZInfo(p pvz.PVZ) PVZInfo {
+	return PVZInfo{
+		ID:                  p.ID,
+		City:                p.City,
+		Timezone:            p.Timezone,
+		CreatedAt:           p.CreatedAt,
+		StaleReceptionAfter: p.StaleReceptionAfter,
+		Name:                p.Name,
+		Address:             p.Address,
+		WorkingHours:        p.WorkingHours,
+		DecommissionedAt:    p.DecommissionedAt,
+		Coordinates:         p.Coordinates,
 	}
-	return city
 }
 
-func normalizeCity(city string) (string, bool) {
-	c := strings.TrimSpace(city)
+func toReceptionInfo(r reception.Reception) ReceptionInfo {
+	return ReceptionInfo{
+		ID:           r.ID,
+		StartedAt:    r.StartedAt,
+		Status:       r.Status,
+		ClosedAt:     r.ClosedAt,
+		OpenedBy:     r.OpenedBy,
+		ClosedBy:     r.ClosedBy,
+		ProductCount: r.ProductCount,
+	}
+}
 
-	for _, allowed := range pvz.AllowedCities {
-		if strings.EqualFold(c, allowed) {
-			return allowed, true
-		}
-		if strings.EqualFold(c, pvzCityEnglish(allowed)) {
-			return allowed, true
-		}
+func toProductInfo(pr product.Product) ProductInfo {
+	return ProductInfo{
+		ID:                   pr.ID,
+		AddedAt:              pr.AddedAt,
+		Type:                 pr.Type,
+		Barcode:              pr.Barcode,
+		SKU:                  pr.SKU,
+		WeightGrams:          pr.WeightGrams,
+		DeclaredValueKopecks: pr.DeclaredValueKopecks,
 	}
-	return "", false
 }
@@ -0,0 +1,193 @@
+package reception
+
+import (
+	"context"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/google/uuid"
+)
+
+const MaxBatchSize = 500
+
+type BatchMode string
+
+const (
+	// BatchAllOrNothing inserts nothing if any item is invalid.
+	BatchAllOrNothing BatchMode = "all_or_nothing"
+	// BatchBestEffort inserts the valid items and reports the rest.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+func (m BatchMode) Valid() bool {
+	return m == BatchAllOrNothing || m == BatchBestEffort
+}
+
+type BatchItem struct {
+	Type    string
+	Details ProductDetails
+}
+
+// BatchResult is the outcome of one item: Product is set when it was added,
+// Err otherwise.
+type BatchResult struct {
+	Product *product.Product
+	Err     error
+}
+
+// AddProducts adds a scanner batch to the open reception of the PVZ in one
+// transaction. Items are checked in order against the type catalog, the PVZ
+// capacity and the barcodes already received, so earlier items of the batch
+// count towards the limits of later ones. In all-or-nothing mode a failed
+// item rejects the whole batch with ErrBatchRejected; the results still tell
+// which items were at fault.
+func (s *Service) AddProducts(ctx context.Context, pvzID string, items []BatchItem, mode BatchMode) ([]BatchResult, error) {
+	if !mode.Valid() {
+		return nil, ErrInvalidMode
+	}
+	if len(items) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if len(items) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	results := make([]BatchResult, len(items))
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		p, err := lockPVZ(ctx, tx, pvzID)
+		if err != nil {
+			return err
+		}
+		openRec, err := tx.ReceptionRepo().GetOpenByPVZ(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if openRec == nil {
+			return reception.ErrNoOpenReception
+		}
+
+		check, err := s.newBatchCheck(ctx, tx, pvzID, openRec.ID, items)
+		if err != nil {
+			return err
+		}
+		now := s.clock.Now()
+		var accepted []*product.Product
+		for i, item := range items {
+			if err := check.admit(item); err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i].Product = &product.Product{
+				ID:          uuid.New().String(),
+				ReceptionID: openRec.ID,
+				AddedAt:     now,
+				Type:        item.Type,
+
+				Barcode:              item.Details.Barcode,
+				SKU:                  item.Details.SKU,
+				WeightGrams:          item.Details.WeightGrams,
+				DeclaredValueKopecks: item.Details.DeclaredValueKopecks,
+			}
+			accepted = append(accepted, results[i].Product)
+		}
+
+		if mode == BatchAllOrNothing && len(accepted) < len(items) {
+			for i := range results {
+				if results[i].Err == nil {
+					results[i] = BatchResult{Err: ErrNotAddedInBatch}
+				}
+			}
+			return ErrBatchRejected
+		}
+		if len(accepted) == 0 {
+			return nil
+		}
+		if err := tx.ProductRepo().CreateMany(ctx, accepted); err != nil {
+			return err
+		}
+		return s.recordMany(ctx, tx, event.ProductAdded, p, openRec, accepted)
+	})
+	if err != nil {
+		if err == ErrBatchRejected {
+			return results, err
+		}
+		return nil, err
+	}
+	return results, nil
+}
+
+// batchCheck keeps running totals so each item is validated against the
+// reception as it will be once the preceding accepted items are in.
+type batchCheck struct {
+	capacity pvz.Capacity
+	total    int
+	byType   map[string]int
+	active   map[string]bool
+	barcodes map[string]bool
+}
+
+func (s *Service) newBatchCheck(ctx context.Context, tx ports.Tx, pvzID, receptionID string, items []BatchItem) (*batchCheck, error) {
+	c := &batchCheck{active: map[string]bool{}, barcodes: map[string]bool{}}
+	var (
+		err      error
+		barcodes []string
+	)
+	for _, item := range items {
+		if _, seen := c.active[item.Type]; !seen {
+			if c.active[item.Type], err = s.catalog.IsActive(ctx, item.Type); err != nil {
+				return nil, err
+			}
+		}
+		if item.Details.Barcode != "" {
+			barcodes = append(barcodes, item.Details.Barcode)
+		}
+	}
+	if len(barcodes) > 0 {
+		if c.barcodes, err = tx.ProductRepo().ExistingBarcodes(ctx, receptionID, barcodes); err != nil {
+			return nil, err
+		}
+	}
+	if c.capacity, err = tx.PVZRepo().GetCapacity(ctx, pvzID); err != nil {
+		return nil, err
+	}
+	if c.byType, err = tx.ProductRepo().CountByType(ctx, receptionID); err != nil {
+		return nil, err
+	}
+	for _, n := range c.byType {
+		c.total += n
+	}
+	return c, nil
+}
+
+func (c *batchCheck) admit(item BatchItem) error {
+	candidate := product.Product{
+		Type:                 item.Type,
+		Barcode:              item.Details.Barcode,
+		SKU:                  item.Details.SKU,
+		WeightGrams:          item.Details.WeightGrams,
+		DeclaredValueKopecks: item.Details.DeclaredValueKopecks,
+	}
+	if err := candidate.ValidateDetails(); err != nil {
+		return err
+	}
+	if !c.active[item.Type] {
+		return product.ErrInvalidType
+	}
+	if item.Details.Barcode != "" && c.barcodes[item.Details.Barcode] {
+		return product.ErrDuplicateBarcode
+	}
+	if err := c.capacity.Admit(c.total, c.byType[item.Type], item.Type); err != nil {
+		return err
+	}
+
+	c.total++
+	c.byType[item.Type]++
+	if item.Details.Barcode != "" {
+		c.barcodes[item.Details.Barcode] = true
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package reception
+
+import "errors"
+
+var (
+	ErrEmptyBatch      = errors.New("пустой пакет товаров")
+	ErrBatchTooLarge   = errors.New("слишком много товаров в пакете")
+	ErrInvalidMode     = errors.New("неизвестный режим пакетной загрузки")
+	ErrBatchRejected   = errors.New("пакет отклонён: есть ошибки в товарах")
+	ErrNotAddedInBatch = errors.New("товар не добавлен: пакет отклонён")
+)
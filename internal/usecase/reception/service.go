@@ -2,148 +2,461 @@ package reception
 
 import (
 	"context"
+	"time"
 
+	"pvz-service/internal/domain/audit"
+	"pvz-service/internal/domain/event"
 	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/pvz"
 	"pvz-service/internal/domain/reception"
 	"pvz-service/internal/usecase/ports"
 
 	"github.com/google/uuid"
 )
 
+// DefaultReopenWindow is how long after closing a reception may be reopened.
+const DefaultReopenWindow = 24 * time.Hour
+
 type Service struct {
-	pvzRepo       ports.PVZRepository
-	receptionRepo ports.ReceptionRepository
-	productRepo   ports.ProductRepository
-	clock         ports.Clock
+	txManager    ports.TxManager
+	catalog      ports.ProductCatalog
+	clock        ports.Clock
+	reopenWindow time.Duration
 }
 
-func NewService(pvzRepo ports.PVZRepository, receptionRepo ports.ReceptionRepository, productRepo ports.ProductRepository, clock ports.Clock) *Service {
-	return &Service{pvzRepo: pvzRepo, receptionRepo: receptionRepo, productRepo: productRepo, clock: clock}
+func NewService(txManager ports.TxManager, catalog ports.ProductCatalog, clock ports.Clock, reopenWindow time.Duration) *Service {
+	return &Service{txManager: txManager, catalog: catalog, clock: clock, reopenWindow: reopenWindow}
 }
 
-func (s *Service) Open(ctx context.Context, pvzID string) (*reception.Reception, error) {
-	p, err := s.pvzRepo.Get(ctx, pvzID)
+func (s *Service) inTx(ctx context.Context, fn func(tx ports.Tx) error) (err error) {
+	tx, err := s.txManager.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if p == nil {
-		return nil, reception.ErrNoOpenReception
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	if err = fn(tx); err != nil {
+		return err
 	}
+	return tx.Commit()
+}
 
-	openRec, err := s.receptionRepo.GetOpenByPVZ(ctx, pvzID)
+// lockPVZ serializes all reception state transitions of one PVZ until the transaction ends.
+func lockPVZ(ctx context.Context, tx ports.Tx, pvzID string) (*pvz.PVZ, error) {
+	p, err := tx.PVZRepo().GetForUpdate(ctx, pvzID)
 	if err != nil {
 		return nil, err
 	}
-	if openRec != nil {
-		return nil, reception.ErrReceptionAlreadyOpen
+	if p == nil {
+		return nil, pvz.ErrNotFound
 	}
+	return p, nil
+}
 
-	rec := &reception.Reception{
-		ID:        uuid.New().String(),
-		PVZID:     pvzID,
-		StartedAt: s.clock.Now(),
-		Status:    reception.StatusInProgress,
+// record stores the event in the outbox and the matching audit entry within
+// the current transaction. The outbox is the only way events leave the
+// service: the relay and the gRPC watch streams both read them from there.
+func (s *Service) record(ctx context.Context, tx ports.Tx, t event.Type, p *pvz.PVZ, rec *reception.Reception, prod *product.Product) error {
+	e := event.DomainEvent{
+		ID:         uuid.New().String(),
+		Type:       t,
+		PVZID:      p.ID,
+		City:       p.City,
+		Reception:  rec,
+		Product:    prod,
+		OccurredAt: s.clock.Now(),
 	}
+	if err := tx.OutboxRepo().Add(ctx, e); err != nil {
+		return err
+	}
+	return tx.AuditRepo().Append(ctx, audit.ForEvent(ctx, uuid.New().String(), e))
+}
 
-	if err := s.receptionRepo.Create(ctx, rec); err != nil {
-		return nil, err
+// recordMany is record for one event per product, written in two round trips.
+func (s *Service) recordMany(ctx context.Context, tx ports.Tx, t event.Type, p *pvz.PVZ, rec *reception.Reception, prods []*product.Product) error {
+	now := s.clock.Now()
+	events := make([]event.DomainEvent, 0, len(prods))
+	entries := make([]audit.Entry, 0, len(prods))
+	for _, prod := range prods {
+		e := event.DomainEvent{
+			ID:         uuid.New().String(),
+			Type:       t,
+			PVZID:      p.ID,
+			City:       p.City,
+			Reception:  rec,
+			Product:    prod,
+			OccurredAt: now,
+		}
+		events = append(events, e)
+		entries = append(entries, audit.ForEvent(ctx, uuid.New().String(), e))
 	}
-	return rec, nil
+	if err := tx.OutboxRepo().AddMany(ctx, events); err != nil {
+		return err
+	}
+	return tx.AuditRepo().AppendMany(ctx, entries)
 }
 
-func (s *Service) AddProduct(ctx context.Context, pvzID string, productType string) (*product.Product, error) {
-	p, err := s.pvzRepo.Get(ctx, pvzID)
+func (s *Service) Open(ctx context.Context, pvzID string) (*reception.Reception, error) {
+	var rec *reception.Reception
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		p, err := lockPVZ(ctx, tx, pvzID)
+		if err != nil {
+			return err
+		}
+		if !p.Active() {
+			return pvz.ErrDecommissioned
+		}
+
+		openRec, err := tx.ReceptionRepo().GetOpenByPVZ(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if !reception.CanOpenNew(openRec) {
+			return reception.ErrReceptionAlreadyOpen
+		}
+
+		actorID, _ := audit.Actor(ctx)
+		rec = &reception.Reception{
+			ID:        uuid.New().String(),
+			PVZID:     pvzID,
+			StartedAt: s.clock.Now(),
+			Status:    reception.StatusInProgress,
+			OpenedBy:  actorID,
+		}
+		if err := tx.ReceptionRepo().Create(ctx, rec); err != nil {
+			return err
+		}
+		return s.record(ctx, tx, event.ReceptionOpened, p, rec, nil)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if p == nil {
-		return nil, reception.ErrNoOpenReception
-	}
+	return rec, nil
+}
 
-	openRec, err := s.receptionRepo.GetOpenByPVZ(ctx, pvzID)
+// admit enforces the PVZ capacity. The caller holds the PVZ lock, so the
+// counts cannot change before the product is inserted.
+func admit(ctx context.Context, tx ports.Tx, pvzID, receptionID, productType string) error {
+	capacity, err := tx.PVZRepo().GetCapacity(ctx, pvzID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if openRec == nil {
-		return nil, reception.ErrNoOpenReception
+	if !capacity.Limited() {
+		return nil
 	}
-
-	validType := false
-	for _, t := range product.AllowedTypes {
-		if t == productType {
-			validType = true
-			break
+	total, err := tx.ProductRepo().CountByReception(ctx, receptionID)
+	if err != nil {
+		return err
+	}
+	ofType := 0
+	if _, ok := capacity.MaxPerType[productType]; ok {
+		ofType, err = tx.ProductRepo().CountByReceptionAndType(ctx, receptionID, productType)
+		if err != nil {
+			return err
 		}
 	}
-	if !validType {
-		return nil, product.ErrInvalidType
+	return capacity.Admit(total, ofType, productType)
+}
+
+type ProductDetails struct {
+	Barcode              string
+	SKU                  string
+	WeightGrams          int
+	DeclaredValueKopecks int64
+}
+
+func (s *Service) AddProduct(ctx context.Context, pvzID string, productType string) (*product.Product, error) {
+	return s.addProduct(ctx, pvzID, productType, ProductDetails{})
+}
+
+// AddScannedProduct adds an item identified by its barcode; the same barcode
+// cannot be received twice within one reception.
+func (s *Service) AddScannedProduct(ctx context.Context, pvzID string, productType string, details ProductDetails) (*product.Product, error) {
+	if details.Barcode == "" {
+		return nil, product.ErrInvalidBarcode
 	}
+	return s.addProduct(ctx, pvzID, productType, details)
+}
 
-	prod := &product.Product{
-		ID:          uuid.New().String(),
-		ReceptionID: openRec.ID,
-		AddedAt:     s.clock.Now(),
-		Type:        productType,
+func (s *Service) addProduct(ctx context.Context, pvzID string, productType string, details ProductDetails) (*product.Product, error) {
+	candidate := product.Product{
+		Type:                 productType,
+		Barcode:              details.Barcode,
+		SKU:                  details.SKU,
+		WeightGrams:          details.WeightGrams,
+		DeclaredValueKopecks: details.DeclaredValueKopecks,
+	}
+	if err := candidate.ValidateDetails(); err != nil {
+		return nil, err
 	}
 
-	if err := s.productRepo.Create(ctx, prod); err != nil {
+	var prod *product.Product
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		p, err := lockPVZ(ctx, tx, pvzID)
+		if err != nil {
+			return err
+		}
+
+		openRec, err := tx.ReceptionRepo().GetOpenByPVZ(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if openRec == nil {
+			return reception.ErrNoOpenReception
+		}
+
+		validType, err := s.catalog.IsActive(ctx, productType)
+		if err != nil {
+			return err
+		}
+		if !validType {
+			return product.ErrInvalidType
+		}
+		if err := admit(ctx, tx, pvzID, openRec.ID, productType); err != nil {
+			return err
+		}
+
+		prod = &candidate
+		prod.ID = uuid.New().String()
+		prod.ReceptionID = openRec.ID
+		prod.AddedAt = s.clock.Now()
+		if err := tx.ProductRepo().Create(ctx, prod); err != nil {
+			return err
+		}
+		return s.record(ctx, tx, event.ProductAdded, p, openRec, prod)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return prod, nil
 }
 
 func (s *Service) RemoveProduct(ctx context.Context, pvzID string) (*product.Product, error) {
-	p, err := s.pvzRepo.Get(ctx, pvzID)
-	if err != nil {
-		return nil, err
-	}
-	if p == nil {
-		return nil, reception.ErrNoOpenReception
-	}
+	var lastProd *product.Product
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		p, err := lockPVZ(ctx, tx, pvzID)
+		if err != nil {
+			return err
+		}
 
-	openRec, err := s.receptionRepo.GetOpenByPVZ(ctx, pvzID)
+		openRec, err := tx.ReceptionRepo().GetOpenByPVZ(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if openRec == nil {
+			return reception.ErrNoOpenReception
+		}
+
+		lastProd, err = tx.ProductRepo().GetLastByReception(ctx, openRec.ID)
+		if err != nil {
+			return err
+		}
+		if lastProd == nil {
+			return reception.ErrNoProducts
+		}
+		return s.removeProduct(ctx, tx, p, openRec, lastProd)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if openRec == nil {
-		return nil, reception.ErrNoOpenReception
-	}
+	return lastProd, nil
+}
+
+// RemoveProductByID removes any product of the PVZ's currently open reception.
+func (s *Service) RemoveProductByID(ctx context.Context, pvzID, productID string) (*product.Product, error) {
+	var prod *product.Product
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		p, err := lockPVZ(ctx, tx, pvzID)
+		if err != nil {
+			return err
+		}
 
-	lastProd, err := s.productRepo.GetLastByReception(ctx, openRec.ID)
+		prod, err = tx.ProductRepo().Get(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if prod == nil {
+			return product.ErrNotFound
+		}
+		rec, err := tx.ReceptionRepo().Get(ctx, prod.ReceptionID)
+		if err != nil {
+			return err
+		}
+		if rec == nil || rec.PVZID != pvzID {
+			return product.ErrNotFound
+		}
+		if !reception.IsOpen(rec.Status) {
+			return reception.ErrReceptionClosed
+		}
+
+		return s.removeProduct(ctx, tx, p, rec, prod)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if lastProd == nil {
-		return nil, reception.ErrNoProducts
-	}
+	return prod, nil
+}
 
-	if err := s.productRepo.Delete(ctx, lastProd.ID); err != nil {
-		return nil, err
+func (s *Service) removeProduct(ctx context.Context, tx ports.Tx, p *pvz.PVZ, rec *reception.Reception, prod *product.Product) error {
+	actorID, _ := audit.Actor(ctx)
+	if err := tx.ProductRepo().Delete(ctx, prod.ID, actorID, s.clock.Now()); err != nil {
+		return err
 	}
-	return lastProd, nil
+	return s.record(ctx, tx, event.ProductRemoved, p, rec, prod)
 }
 
 func (s *Service) Close(ctx context.Context, pvzID string) (*reception.Reception, error) {
-	p, err := s.pvzRepo.Get(ctx, pvzID)
+	var openRec *reception.Reception
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		p, err := lockPVZ(ctx, tx, pvzID)
+		if err != nil {
+			return err
+		}
+
+		openRec, err = tx.ReceptionRepo().GetOpenByPVZ(ctx, pvzID)
+		if err != nil {
+			return err
+		}
+		if openRec == nil {
+			return reception.ErrNoOpenReception
+		}
+		return s.closeReception(ctx, tx, p, openRec)
+	})
 	if err != nil {
 		return nil, err
 	}
-	if p == nil {
-		return nil, reception.ErrNoOpenReception
-	}
+	return openRec, nil
+}
 
-	openRec, err := s.receptionRepo.GetOpenByPVZ(ctx, pvzID)
+func (s *Service) closeReception(ctx context.Context, tx ports.Tx, p *pvz.PVZ, rec *reception.Reception) error {
+	count, err := tx.ProductRepo().CountByReception(ctx, rec.ID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	actorID, _ := audit.Actor(ctx)
+	if err := rec.Close(actorID, s.clock.Now(), count); err != nil {
+		return err
 	}
-	if openRec == nil {
-		return nil, reception.ErrNoOpenReception
+	if err := tx.ReceptionRepo().UpdateStatus(ctx, rec); err != nil {
+		return err
 	}
+	return s.record(ctx, tx, event.ReceptionClosed, p, rec, nil)
+}
 
-	if err := s.receptionRepo.Close(ctx, openRec.ID); err != nil {
+// Reopen returns a recently closed reception to work. The PVZ must not have
+// another open reception.
+func (s *Service) Reopen(ctx context.Context, receptionID string) (*reception.Reception, error) {
+	return s.transition(ctx, receptionID, event.ReceptionReopened, func(tx ports.Tx, p *pvz.PVZ, rec *reception.Reception) error {
+		if !p.Active() {
+			return pvz.ErrDecommissioned
+		}
+		openRec, err := tx.ReceptionRepo().GetOpenByPVZ(ctx, rec.PVZID)
+		if err != nil {
+			return err
+		}
+		if !reception.CanOpenNew(openRec) {
+			return reception.ErrReceptionAlreadyOpen
+		}
+		return rec.Reopen(s.clock.Now(), s.reopenWindow)
+	})
+}
+
+// Cancel discards a reception opened by mistake: any open one, or a closed
+// one without products.
+func (s *Service) Cancel(ctx context.Context, receptionID string) (*reception.Reception, error) {
+	return s.transition(ctx, receptionID, event.ReceptionCancelled, func(tx ports.Tx, _ *pvz.PVZ, rec *reception.Reception) error {
+		count, err := tx.ProductRepo().CountByReception(ctx, rec.ID)
+		if err != nil {
+			return err
+		}
+		actorID, _ := audit.Actor(ctx)
+		return rec.Cancel(actorID, s.clock.Now(), count)
+	})
+}
+
+// Delete hides a cancelled reception, with its products, from listings and
+// lookups. The row stays in the database along with who deleted it.
+func (s *Service) Delete(ctx context.Context, receptionID string) error {
+	return s.inTx(ctx, func(tx ports.Tx) error {
+		rec, err := tx.ReceptionRepo().Get(ctx, receptionID)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return reception.ErrNotFound
+		}
+		if _, err := lockPVZ(ctx, tx, rec.PVZID); err != nil {
+			return err
+		}
+		rec, err = tx.ReceptionRepo().Get(ctx, receptionID)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return reception.ErrNotFound
+		}
+		if err := rec.CanDelete(); err != nil {
+			return err
+		}
+
+		now := s.clock.Now()
+		actorID, actorRole := audit.Actor(ctx)
+		if err := tx.ReceptionRepo().Delete(ctx, rec.ID, actorID, now); err != nil {
+			return err
+		}
+		return tx.AuditRepo().Append(ctx, audit.Entry{
+			ID:          uuid.New().String(),
+			OccurredAt:  now,
+			ActorID:     actorID,
+			ActorRole:   actorRole,
+			Action:      audit.ActionReceptionDeleted,
+			PVZID:       rec.PVZID,
+			ReceptionID: rec.ID,
+			Details: map[string]string{
+				"receptionStatus": rec.Status,
+				"startedAt":       rec.StartedAt.Format(time.RFC3339),
+			},
+		})
+	})
+}
+
+func (s *Service) transition(ctx context.Context, receptionID string, t event.Type, apply func(tx ports.Tx, p *pvz.PVZ, rec *reception.Reception) error) (*reception.Reception, error) {
+	var rec *reception.Reception
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		var err error
+		rec, err = tx.ReceptionRepo().Get(ctx, receptionID)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return reception.ErrNotFound
+		}
+		p, err := lockPVZ(ctx, tx, rec.PVZID)
+		if err != nil {
+			return err
+		}
+		// re-read under the PVZ lock so the transition sees the latest status
+		rec, err = tx.ReceptionRepo().Get(ctx, receptionID)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			return reception.ErrNotFound
+		}
+
+		if err := apply(tx, p, rec); err != nil {
+			return err
+		}
+		if err := tx.ReceptionRepo().UpdateStatus(ctx, rec); err != nil {
+			return err
+		}
+		return s.record(ctx, tx, t, p, rec, nil)
+	})
+	if err != nil {
 		return nil, err
 	}
-
-	openRec.Status = reception.StatusClosed
-	return openRec, nil
+	return rec, nil
 }
@@ -0,0 +1,163 @@
+package reception
+
+import (
+	"context"
+	"time"
+
+	"pvz-service/internal/domain/audit"
+	"pvz-service/internal/domain/reception"
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// What the sweeper does with a stale reception.
+const (
+	StaleActionClose = "close"
+	StaleActionFlag  = "flag"
+)
+
+type SweeperConfig struct {
+	Interval  time.Duration
+	MaxAge    time.Duration
+	Action    string
+	BatchSize int
+}
+
+// Sweeper finds receptions left open longer than allowed and closes them on
+// behalf of the system, or only flags them for a moderator. A reopened
+// reception is aged from its reopening.
+type Sweeper struct {
+	svc     *Service
+	metrics ports.Metrics
+	logger  *zap.Logger
+	cfg     SweeperConfig
+}
+
+func NewSweeper(svc *Service, metrics ports.Metrics, logger *zap.Logger, cfg SweeperConfig) *Sweeper {
+	return &Sweeper{svc: svc, metrics: metrics, logger: logger, cfg: cfg}
+}
+
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := s.Sweep(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger.Error("stale reception sweep failed", zap.String("action", s.cfg.Action), zap.Error(err))
+				}
+				break
+			}
+			if n < s.cfg.BatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep handles one batch of stale receptions and returns how many were found.
+func (s *Sweeper) Sweep(ctx context.Context) (int, error) {
+	ctx = audit.SystemContext(ctx)
+	flag := s.cfg.Action == StaleActionFlag
+
+	var stale []reception.Reception
+	err := s.svc.inTx(ctx, func(tx ports.Tx) error {
+		var err error
+		stale, err = tx.ReceptionRepo().ListStale(ctx, ports.StaleReceptionQuery{
+			Now:           s.svc.clock.Now(),
+			DefaultMaxAge: s.cfg.MaxAge,
+			UnflaggedOnly: flag,
+			Limit:         s.cfg.BatchSize,
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, rec := range stale {
+		var handled bool
+		if flag {
+			handled, err = s.svc.flagStale(ctx, rec)
+		} else {
+			handled, err = s.svc.closeStale(ctx, rec)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if handled {
+			s.metrics.IncStaleReception(s.cfg.Action)
+		}
+	}
+	return len(stale), nil
+}
+
+// closeStale closes rec unless it has changed since it was found stale.
+func (s *Service) closeStale(ctx context.Context, rec reception.Reception) (bool, error) {
+	closed := false
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		p, err := lockPVZ(ctx, tx, rec.PVZID)
+		if err != nil {
+			return err
+		}
+		openRec, err := tx.ReceptionRepo().GetOpenByPVZ(ctx, rec.PVZID)
+		if err != nil {
+			return err
+		}
+		if openRec == nil || openRec.ID != rec.ID || !sameTime(openRec.ReopenedAt, rec.ReopenedAt) {
+			return nil
+		}
+		err = s.closeReception(ctx, tx, p, openRec)
+		closed = err == nil
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return closed, nil
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// flagStale flags rec unless another sweeper has flagged it first.
+func (s *Service) flagStale(ctx context.Context, rec reception.Reception) (bool, error) {
+	flagged := false
+	err := s.inTx(ctx, func(tx ports.Tx) error {
+		now := s.clock.Now()
+		n, err := tx.ReceptionRepo().MarkStale(ctx, rec.ID, now)
+		if err != nil || n == 0 {
+			return err
+		}
+		flagged = true
+		return tx.AuditRepo().Append(ctx, audit.Entry{
+			ID:          uuid.New().String(),
+			OccurredAt:  now,
+			ActorID:     audit.SystemActorID,
+			ActorRole:   audit.SystemActorRole,
+			Action:      audit.ActionReceptionFlaggedStale,
+			PVZID:       rec.PVZID,
+			ReceptionID: rec.ID,
+			Details: map[string]string{
+				"receptionStatus": rec.Status,
+				"startedAt":       rec.StartedAt.Format(time.RFC3339),
+			},
+		})
+	})
+	if err != nil {
+		return false, err
+	}
+	return flagged, nil
+}
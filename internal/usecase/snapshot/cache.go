@@ -0,0 +1,58 @@
+package snapshot
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"pvz-service/internal/usecase/ports"
+)
+
+// Cache keeps the last value returned by load for ttl. Invalidate only affects
+// this process: another binary sharing the database keeps serving its copy
+// until its own ttl runs out.
+type Cache[T any] struct {
+	load  func(ctx context.Context) (T, error)
+	clock ports.Clock
+	ttl   time.Duration
+
+	mu         sync.RWMutex
+	value      T
+	loaded     bool
+	loadedAt   time.Time
+	generation uint64
+}
+
+func New[T any](clock ports.Clock, ttl time.Duration, load func(ctx context.Context) (T, error)) *Cache[T] {
+	return &Cache[T]{load: load, clock: clock, ttl: ttl}
+}
+
+func (c *Cache[T]) Get(ctx context.Context) (T, error) {
+	c.mu.RLock()
+	value, loaded, loadedAt, generation := c.value, c.loaded, c.loadedAt, c.generation
+	c.mu.RUnlock()
+	if loaded && c.clock.Now().Sub(loadedAt) < c.ttl {
+		return value, nil
+	}
+
+	value, err := c.load(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	c.mu.Lock()
+	// a load that raced with Invalidate may have read the old data
+	if c.generation == generation {
+		c.value, c.loaded, c.loadedAt = value, true, c.clock.Now()
+	}
+	c.mu.Unlock()
+	return value, nil
+}
+
+func (c *Cache[T]) Invalidate() {
+	c.mu.Lock()
+	var zero T
+	c.value, c.loaded = zero, false
+	c.generation++
+	c.mu.Unlock()
+}
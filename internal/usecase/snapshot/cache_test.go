@@ -0,0 +1,75 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type manualClock struct{ now time.Time }
+
+func (c *manualClock) Now() time.Time { return c.now }
+
+func TestCacheReloadsAfterTTLAndInvalidate(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	loads := 0
+	var failWith error
+	c := New(clock, time.Minute, func(context.Context) (int, error) {
+		if failWith != nil {
+			return 0, failWith
+		}
+		loads++
+		return loads, nil
+	})
+
+	get := func() int {
+		t.Helper()
+		v, err := c.Get(ctx)
+		require.NoError(t, err)
+		return v
+	}
+	require.Equal(t, 1, get())
+	clock.now = clock.now.Add(59 * time.Second)
+	require.Equal(t, 1, get())
+	clock.now = clock.now.Add(time.Second)
+	require.Equal(t, 2, get())
+
+	c.Invalidate()
+	require.Equal(t, 3, get())
+
+	c.Invalidate()
+	failWith = errors.New("db down")
+	_, err := c.Get(ctx)
+	require.ErrorIs(t, err, failWith)
+	failWith = nil
+	require.Equal(t, 4, get())
+}
+
+func TestCacheDropsLoadRacingWithInvalidate(t *testing.T) {
+	ctx := context.Background()
+	clock := &manualClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	version := 1
+	var c *Cache[int]
+	invalidateDuringLoad := true
+	c = New(clock, time.Minute, func(context.Context) (int, error) {
+		v := version
+		if invalidateDuringLoad {
+			// a write lands after the read but before the result is stored
+			invalidateDuringLoad = false
+			version = 2
+			c.Invalidate()
+		}
+		return v, nil
+	})
+
+	v, err := c.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, v, "the caller still gets what was read")
+	v, err = c.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, v, "but the stale value is not cached")
+}
@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pvz-service/internal/domain/webhook"
+	"pvz-service/internal/usecase/backoff"
+	"pvz-service/internal/usecase/ports"
+)
+
+type DispatcherConfig struct {
+	PollInterval time.Duration
+	// Lease is how long claimed deliveries stay hidden from other dispatchers;
+	// it has to outlast sending a whole batch.
+	Lease                  time.Duration
+	BatchSize              int
+	MaxAttempts            int
+	MaxConsecutiveFailures int
+	BaseBackoff            time.Duration
+	MaxBackoff             time.Duration
+}
+
+// Dispatcher sends pending webhook deliveries. Deliveries are claimed for
+// Lease in a short transaction, sent with no transaction open, and each
+// outcome is stored in a transaction of its own; a dispatcher that dies
+// mid-batch leaves the unsent deliveries to be retried when the lease ends.
+type Dispatcher struct {
+	txManager ports.TxManager
+	sender    ports.WebhookSender
+	clock     ports.Clock
+	cfg       DispatcherConfig
+}
+
+func NewDispatcher(txManager ports.TxManager, sender ports.WebhookSender, clock ports.Clock, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{txManager: txManager, sender: sender, clock: clock, cfg: cfg}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		for {
+			n, err := d.ProcessBatch(ctx)
+			if err != nil || n < d.cfg.BatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+type claimedDelivery struct {
+	delivery webhook.Delivery
+	sub      *webhook.Subscription
+}
+
+func (d *Dispatcher) ProcessBatch(ctx context.Context) (int, error) {
+	claimed, err := d.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range claimed {
+		if c.sub == nil {
+			continue
+		}
+		if err := d.deliver(ctx, c.sub, &c.delivery); err != nil {
+			return 0, err
+		}
+	}
+	return len(claimed), nil
+}
+
+func (d *Dispatcher) claim(ctx context.Context) ([]claimedDelivery, error) {
+	tx, err := d.txManager.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	repo := tx.WebhookRepo()
+	now := d.clock.Now()
+	deliveries, err := repo.ClaimPendingDeliveries(ctx, now, now.Add(d.cfg.Lease), d.cfg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	claimed := make([]claimedDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		sub, err := repo.GetSubscription(ctx, delivery.SubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, claimedDelivery{delivery: delivery, sub: sub})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, sub *webhook.Subscription, delivery *webhook.Delivery) error {
+	code, sendErr := d.sender.Send(ctx, sub.URL, sub.Secret, delivery)
+	if sendErr == nil && code >= 200 && code < 300 {
+		return d.store(ctx, func(repo ports.WebhookRepository) error {
+			if err := repo.MarkDelivered(ctx, delivery.ID, code, d.clock.Now()); err != nil {
+				return err
+			}
+			return repo.RecordSuccess(ctx, sub.ID)
+		})
+	}
+
+	lastError := fmt.Sprintf("unexpected status code %d", code)
+	if sendErr != nil {
+		lastError = sendErr.Error()
+	}
+	attempts := delivery.Attempts + 1
+	now := d.clock.Now()
+	next := now.Add(backoff.Exponential(d.cfg.BaseBackoff, d.cfg.MaxBackoff, attempts))
+	return d.store(ctx, func(repo ports.WebhookRepository) error {
+		if err := repo.MarkAttemptFailed(ctx, delivery.ID, attempts, next, code, lastError, attempts >= d.cfg.MaxAttempts); err != nil {
+			return err
+		}
+		return repo.RecordFailure(ctx, sub.ID, d.cfg.MaxConsecutiveFailures, now)
+	})
+}
+
+// store records the outcome of one delivery.
+func (d *Dispatcher) store(ctx context.Context, fn func(ports.WebhookRepository) error) error {
+	tx, err := d.txManager.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err := fn(tx.WebhookRepo()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/reception"
+)
+
+type receptionPayload struct {
+	ID           string     `json:"id"`
+	DateTime     time.Time  `json:"dateTime"`
+	PVZID        string     `json:"pvzId"`
+	Status       string     `json:"status"`
+	ClosedAt     *time.Time `json:"closedAt,omitempty"`
+	OpenedBy     string     `json:"openedBy,omitempty"`
+	ClosedBy     string     `json:"closedBy,omitempty"`
+	ProductCount *int       `json:"productCount,omitempty"`
+}
+
+type productPayload struct {
+	ID                   string    `json:"id"`
+	DateTime             time.Time `json:"dateTime"`
+	Type                 string    `json:"type"`
+	ReceptionID          string    `json:"receptionId"`
+	Barcode              string    `json:"barcode,omitempty"`
+	SKU                  string    `json:"sku,omitempty"`
+	WeightGrams          int       `json:"weightGrams,omitempty"`
+	DeclaredValueKopecks int64     `json:"declaredValueKopecks,omitempty"`
+}
+
+type eventPayload struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	OccurredAt time.Time         `json:"occurredAt"`
+	PVZID      string            `json:"pvzId"`
+	City       string            `json:"city"`
+	Reception  *receptionPayload `json:"reception,omitempty"`
+	Product    *productPayload   `json:"product,omitempty"`
+}
+
+func newReceptionPayload(r *reception.Reception) *receptionPayload {
+	if r == nil {
+		return nil
+	}
+	p := &receptionPayload{
+		ID:       r.ID,
+		DateTime: r.StartedAt,
+		PVZID:    r.PVZID,
+		Status:   r.Status,
+		ClosedAt: r.ClosedAt,
+		OpenedBy: r.OpenedBy,
+		ClosedBy: r.ClosedBy,
+	}
+	if r.ClosedAt != nil {
+		count := r.ProductCount
+		p.ProductCount = &count
+	}
+	return p
+}
+
+func newProductPayload(p *product.Product) *productPayload {
+	if p == nil {
+		return nil
+	}
+	return &productPayload{
+		ID:                   p.ID,
+		DateTime:             p.AddedAt,
+		Type:                 p.Type,
+		ReceptionID:          p.ReceptionID,
+		Barcode:              p.Barcode,
+		SKU:                  p.SKU,
+		WeightGrams:          p.WeightGrams,
+		DeclaredValueKopecks: p.DeclaredValueKopecks,
+	}
+}
+
+func buildPayload(e event.DomainEvent) ([]byte, error) {
+	return json.Marshal(eventPayload{
+		ID:         e.ID,
+		Type:       string(e.Type),
+		OccurredAt: e.OccurredAt,
+		PVZID:      e.PVZID,
+		City:       e.City,
+		Reception:  newReceptionPayload(e.Reception),
+		Product:    newProductPayload(e.Product),
+	})
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"context"
+	"net/url"
+
+	"pvz-service/internal/domain/event"
+	"pvz-service/internal/domain/webhook"
+	"pvz-service/internal/usecase/ports"
+
+	"github.com/google/uuid"
+)
+
+const defaultDeliveriesLimit = 100
+
+type CreateParams struct {
+	URL        string
+	EventTypes []string
+	PVZID      string
+	City       string
+	Secret     string
+}
+
+type Service struct {
+	repo   ports.WebhookRepository
+	cities ports.CityRegistry
+	clock  ports.Clock
+}
+
+func NewService(repo ports.WebhookRepository, cities ports.CityRegistry, clock ports.Clock) *Service {
+	return &Service{repo: repo, cities: cities, clock: clock}
+}
+
+func (s *Service) Create(ctx context.Context, params CreateParams) (*webhook.Subscription, error) {
+	u, err := url.Parse(params.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, webhook.ErrInvalidURL
+	}
+	if params.Secret == "" {
+		return nil, webhook.ErrEmptySecret
+	}
+	if len(params.EventTypes) == 0 {
+		return nil, webhook.ErrInvalidEventType
+	}
+	types := make([]event.Type, 0, len(params.EventTypes))
+	for _, t := range params.EventTypes {
+		et := event.Type(t)
+		if !et.Valid() {
+			return nil, webhook.ErrInvalidEventType
+		}
+		types = append(types, et)
+	}
+	// events carry the canonical city name, so aliases are resolved up front
+	city := params.City
+	if city != "" {
+		if city, err = s.cities.Canonical(ctx, city); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := &webhook.Subscription{
+		ID:         uuid.New().String(),
+		URL:        params.URL,
+		EventTypes: types,
+		PVZID:      params.PVZID,
+		City:       city,
+		Secret:     params.Secret,
+		Active:     true,
+		CreatedAt:  s.clock.Now(),
+	}
+	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]webhook.Subscription, error) {
+	return s.repo.ListSubscriptions(ctx)
+}
+
+func (s *Service) Delete(ctx context.Context, id string) error {
+	found, err := s.repo.DeleteSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return webhook.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Service) Deliveries(ctx context.Context, subscriptionID string, limit int) ([]webhook.Delivery, error) {
+	sub, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, webhook.ErrNotFound
+	}
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	return s.repo.ListDeliveries(ctx, subscriptionID, limit)
+}
+
+// Publish implements ports.OutboxPublisher: it enqueues one delivery per matching subscription.
+func (s *Service) Publish(ctx context.Context, e event.DomainEvent) error {
+	subs, err := s.repo.ListActiveSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	var payload []byte
+	for i := range subs {
+		if !subs[i].Matches(e) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = buildPayload(e); err != nil {
+				return err
+			}
+		}
+		now := s.clock.Now()
+		err := s.repo.CreateDelivery(ctx, &webhook.Delivery{
+			ID:             uuid.New().String(),
+			SubscriptionID: subs[i].ID,
+			EventID:        e.ID,
+			EventType:      e.Type,
+			Payload:        payload,
+			Status:         webhook.DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
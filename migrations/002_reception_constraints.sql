@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pvzs
+    ADD CONSTRAINT pvzs_city_check CHECK (city IN ('Москва', 'Санкт-Петербург', 'Казань'));
+
+ALTER TABLE receptions
+    ADD CONSTRAINT receptions_status_check CHECK (status IN ('in_progress', 'closed'));
+
+ALTER TABLE products
+    ADD CONSTRAINT products_type_check CHECK (type IN ('electronics', 'clothes', 'shoes'));
+
+CREATE UNIQUE INDEX receptions_one_in_progress_per_pvz
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS receptions_one_in_progress_per_pvz;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_type_check;
+ALTER TABLE receptions DROP CONSTRAINT IF EXISTS receptions_status_check;
+ALTER TABLE pvzs DROP CONSTRAINT IF EXISTS pvzs_city_check;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE outbox (
+                        id UUID PRIMARY KEY,
+                        event_type TEXT NOT NULL,
+                        payload JSONB NOT NULL,
+                        status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+                        attempts INT NOT NULL DEFAULT 0,
+                        last_error TEXT,
+                        created_at TIMESTAMP NOT NULL,
+                        next_attempt_at TIMESTAMP NOT NULL,
+                        delivered_at TIMESTAMP
+);
+
+CREATE INDEX outbox_pending_idx ON outbox (next_attempt_at) WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS outbox;
+
+-- +goose StatementEnd
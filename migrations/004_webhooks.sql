@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE webhook_subscriptions (
+                                       id UUID PRIMARY KEY,
+                                       url TEXT NOT NULL,
+                                       event_types TEXT[] NOT NULL,
+                                       pvz_id UUID REFERENCES pvzs(id),
+                                       city TEXT,
+                                       secret TEXT NOT NULL,
+                                       active BOOLEAN NOT NULL DEFAULT TRUE,
+                                       consecutive_failures INT NOT NULL DEFAULT 0,
+                                       created_at TIMESTAMP NOT NULL,
+                                       disabled_at TIMESTAMP
+);
+
+CREATE TABLE webhook_deliveries (
+                                    id UUID PRIMARY KEY,
+                                    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+                                    event_id UUID NOT NULL,
+                                    event_type TEXT NOT NULL,
+                                    payload JSONB NOT NULL,
+                                    status TEXT NOT NULL CHECK (status IN ('pending', 'delivered', 'failed')),
+                                    attempts INT NOT NULL DEFAULT 0,
+                                    next_attempt_at TIMESTAMP NOT NULL,
+                                    last_status_code INT,
+                                    last_error TEXT,
+                                    created_at TIMESTAMP NOT NULL,
+                                    delivered_at TIMESTAMP,
+                                    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_subscription_idx ON webhook_deliveries (subscription_id, created_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+
+-- +goose StatementEnd
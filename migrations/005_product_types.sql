@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE product_types (
+                               code TEXT PRIMARY KEY CHECK (code ~ '^[a-z][a-z0-9_]*$'),
+                               names JSONB NOT NULL DEFAULT '{}',
+                               active BOOLEAN NOT NULL DEFAULT TRUE,
+                               created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+INSERT INTO product_types (code, names) VALUES
+    ('electronics', '{"ru": "электроника", "en": "electronics"}'),
+    ('clothes', '{"ru": "одежда", "en": "clothes"}'),
+    ('shoes', '{"ru": "обувь", "en": "shoes"}');
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_type_check;
+ALTER TABLE products
+    ADD CONSTRAINT products_type_fkey FOREIGN KEY (type) REFERENCES product_types (code);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_type_fkey;
+DROP TABLE IF EXISTS product_types;
+ALTER TABLE products
+    ADD CONSTRAINT products_type_check CHECK (type IN ('electronics', 'clothes', 'shoes'));
+
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE cities (
+                        name TEXT PRIMARY KEY CHECK (btrim(name) <> ''),
+                        aliases JSONB NOT NULL DEFAULT '{}',
+                        timezone TEXT NOT NULL DEFAULT 'Europe/Moscow',
+                        active BOOLEAN NOT NULL DEFAULT TRUE,
+                        created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+INSERT INTO cities (name, aliases, timezone) VALUES
+    ('Москва', '{"ru": "Москва", "en": "Moscow"}', 'Europe/Moscow'),
+    ('Санкт-Петербург', '{"ru": "Санкт-Петербург", "en": "Saint Petersburg"}', 'Europe/Moscow'),
+    ('Казань', '{"ru": "Казань", "en": "Kazan"}', 'Europe/Moscow');
+
+ALTER TABLE pvzs DROP CONSTRAINT IF EXISTS pvzs_city_check;
+ALTER TABLE pvzs
+    ADD CONSTRAINT pvzs_city_fkey FOREIGN KEY (city) REFERENCES cities (name);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pvzs DROP CONSTRAINT IF EXISTS pvzs_city_fkey;
+DROP TABLE IF EXISTS cities;
+ALTER TABLE pvzs
+    ADD CONSTRAINT pvzs_city_check CHECK (city IN ('Москва', 'Санкт-Петербург', 'Казань'));
+
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Existing values are wall-clock time.Now() of the server that wrote them, in
+-- its local zone. pvz-migrator passes that zone as pvz.legacy_timezone (env
+-- MIGRATION_LEGACY_TIMEZONE, falling back to TZ, then UTC); when the migration
+-- is applied by other means UTC is assumed.
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE pvzs
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE receptions
+    ALTER COLUMN started_at TYPE TIMESTAMPTZ USING started_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE products
+    ALTER COLUMN added_at TYPE TIMESTAMPTZ USING added_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE outbox
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMPTZ USING next_attempt_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN delivered_at TYPE TIMESTAMPTZ USING delivered_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN disabled_at TYPE TIMESTAMPTZ USING disabled_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE webhook_deliveries
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMPTZ USING next_attempt_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN delivered_at TYPE TIMESTAMPTZ USING delivered_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE product_types
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE cities
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+
+ALTER TABLE pvzs ADD COLUMN timezone TEXT NOT NULL DEFAULT 'Europe/Moscow';
+UPDATE pvzs p SET timezone = c.timezone FROM cities c WHERE c.name = p.city;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE pvzs DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE pvzs
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE receptions
+    ALTER COLUMN started_at TYPE TIMESTAMP USING started_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE products
+    ALTER COLUMN added_at TYPE TIMESTAMP USING added_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE outbox
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMP USING next_attempt_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN delivered_at TYPE TIMESTAMP USING delivered_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN disabled_at TYPE TIMESTAMP USING disabled_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE webhook_deliveries
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMP USING next_attempt_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC'),
+    ALTER COLUMN delivered_at TYPE TIMESTAMP USING delivered_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE product_types
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+ALTER TABLE cities
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE COALESCE(NULLIF(current_setting('pvz.legacy_timezone', true), ''), 'UTC');
+
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE products
+    ADD COLUMN barcode TEXT CHECK (barcode IS NULL OR barcode ~ '^[0-9A-Za-z-]{4,64}$'),
+    ADD COLUMN sku TEXT,
+    ADD COLUMN weight_grams INTEGER CHECK (weight_grams IS NULL OR weight_grams > 0),
+    ADD COLUMN declared_value_kopecks BIGINT CHECK (declared_value_kopecks IS NULL OR declared_value_kopecks >= 0);
+
+CREATE UNIQUE INDEX products_reception_barcode_key
+    ON products (reception_id, barcode)
+    WHERE barcode IS NOT NULL;
+
+CREATE INDEX products_barcode_idx
+    ON products (barcode)
+    WHERE barcode IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS products_barcode_idx;
+DROP INDEX IF EXISTS products_reception_barcode_key;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS declared_value_kopecks,
+    DROP COLUMN IF EXISTS weight_grams,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS barcode;
+
+-- +goose StatementEnd
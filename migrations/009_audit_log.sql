@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE audit_log (
+                           id UUID PRIMARY KEY,
+                           occurred_at TIMESTAMPTZ NOT NULL,
+                           actor_id TEXT NOT NULL DEFAULT '',
+                           actor_role TEXT NOT NULL DEFAULT '',
+                           action TEXT NOT NULL,
+                           pvz_id UUID,
+                           reception_id UUID,
+                           product_id UUID,
+                           details JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX audit_log_pvz_idx ON audit_log (pvz_id, occurred_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS audit_log;
+
+-- +goose StatementEnd
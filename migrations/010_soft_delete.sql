@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE products
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by TEXT;
+
+ALTER TABLE receptions
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by TEXT;
+
+DROP INDEX IF EXISTS products_reception_barcode_key;
+CREATE UNIQUE INDEX products_reception_barcode_key
+    ON products (reception_id, barcode)
+    WHERE barcode IS NOT NULL AND deleted_at IS NULL;
+
+DROP INDEX IF EXISTS receptions_one_in_progress_per_pvz;
+CREATE UNIQUE INDEX receptions_one_in_progress_per_pvz
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress' AND deleted_at IS NULL;
+
+CREATE INDEX audit_log_actor_idx ON audit_log (actor_id, occurred_at);
+CREATE INDEX audit_log_occurred_at_idx ON audit_log (occurred_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS audit_log_occurred_at_idx;
+DROP INDEX IF EXISTS audit_log_actor_idx;
+
+DROP INDEX IF EXISTS receptions_one_in_progress_per_pvz;
+CREATE UNIQUE INDEX receptions_one_in_progress_per_pvz
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress';
+
+DROP INDEX IF EXISTS products_reception_barcode_key;
+CREATE UNIQUE INDEX products_reception_barcode_key
+    ON products (reception_id, barcode)
+    WHERE barcode IS NOT NULL;
+
+DELETE FROM products WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE receptions
+    ADD COLUMN closed_at TIMESTAMPTZ,
+    ADD COLUMN opened_by TEXT,
+    ADD COLUMN closed_by TEXT,
+    ADD COLUMN product_count INTEGER;
+
+UPDATE receptions r
+SET product_count = (SELECT count(*) FROM products p WHERE p.reception_id = r.id AND p.deleted_at IS NULL)
+WHERE r.status = 'closed';
+
+CREATE INDEX receptions_closed_at_idx ON receptions (closed_at) WHERE closed_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS receptions_closed_at_idx;
+
+ALTER TABLE receptions
+    DROP COLUMN IF EXISTS product_count,
+    DROP COLUMN IF EXISTS closed_by,
+    DROP COLUMN IF EXISTS opened_by,
+    DROP COLUMN IF EXISTS closed_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE receptions DROP CONSTRAINT IF EXISTS receptions_status_check;
+ALTER TABLE receptions
+    ADD CONSTRAINT receptions_status_check CHECK (status IN ('in_progress', 'closed', 'cancelled', 'reopened'));
+
+DROP INDEX IF EXISTS receptions_one_in_progress_per_pvz;
+CREATE UNIQUE INDEX receptions_one_in_progress_per_pvz
+    ON receptions (pvz_id)
+    WHERE status IN ('in_progress', 'reopened') AND deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+UPDATE receptions SET status = 'in_progress' WHERE status = 'reopened';
+UPDATE receptions SET status = 'closed' WHERE status = 'cancelled';
+
+DROP INDEX IF EXISTS receptions_one_in_progress_per_pvz;
+CREATE UNIQUE INDEX receptions_one_in_progress_per_pvz
+    ON receptions (pvz_id)
+    WHERE status = 'in_progress' AND deleted_at IS NULL;
+
+ALTER TABLE receptions DROP CONSTRAINT IF EXISTS receptions_status_check;
+ALTER TABLE receptions
+    ADD CONSTRAINT receptions_status_check CHECK (status IN ('in_progress', 'closed'));
+
+-- +goose StatementEnd
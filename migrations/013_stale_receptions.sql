@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pvzs
+    ADD COLUMN stale_reception_after_seconds INTEGER CHECK (stale_reception_after_seconds > 0);
+
+ALTER TABLE receptions
+    ADD COLUMN stale_flagged_at TIMESTAMPTZ;
+
+CREATE INDEX receptions_in_progress_started_at_idx
+    ON receptions (started_at)
+    WHERE status = 'in_progress' AND deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS receptions_in_progress_started_at_idx;
+
+ALTER TABLE receptions DROP COLUMN IF EXISTS stale_flagged_at;
+
+ALTER TABLE pvzs DROP COLUMN IF EXISTS stale_reception_after_seconds;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pvzs
+    ADD COLUMN max_products_per_reception INTEGER CHECK (max_products_per_reception > 0);
+
+CREATE TABLE pvz_type_quotas (
+    pvz_id UUID NOT NULL REFERENCES pvzs (id) ON DELETE CASCADE,
+    product_type TEXT NOT NULL REFERENCES product_types (code) ON DELETE CASCADE,
+    max_products INTEGER NOT NULL CHECK (max_products > 0),
+    PRIMARY KEY (pvz_id, product_type)
+);
+
+CREATE INDEX products_reception_type_idx ON products (reception_id, type) WHERE deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS products_reception_type_idx;
+DROP TABLE IF EXISTS pvz_type_quotas;
+ALTER TABLE pvzs DROP COLUMN IF EXISTS max_products_per_reception;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pvzs
+    ADD COLUMN name TEXT NOT NULL DEFAULT '',
+    ADD COLUMN address TEXT NOT NULL DEFAULT '',
+    ADD COLUMN working_hours JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN decommissioned_at TIMESTAMPTZ;
+
+CREATE INDEX pvzs_active_created_idx ON pvzs (created_at, id) WHERE decommissioned_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS pvzs_active_created_idx;
+ALTER TABLE pvzs
+    DROP COLUMN IF EXISTS decommissioned_at,
+    DROP COLUMN IF EXISTS working_hours,
+    DROP COLUMN IF EXISTS address,
+    DROP COLUMN IF EXISTS name;
+
+-- +goose StatementEnd
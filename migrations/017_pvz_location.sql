@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pvzs
+    ADD COLUMN latitude DOUBLE PRECISION CHECK (latitude BETWEEN -90 AND 90),
+    ADD COLUMN longitude DOUBLE PRECISION CHECK (longitude BETWEEN -180 AND 180),
+    ADD CONSTRAINT pvzs_location_complete CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+-- the nearest search scans located active PVZs; a bounding box is not worth it at this size
+CREATE INDEX pvzs_located_idx ON pvzs (latitude, longitude)
+    WHERE latitude IS NOT NULL AND decommissioned_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS pvzs_located_idx;
+ALTER TABLE pvzs
+    DROP CONSTRAINT IF EXISTS pvzs_location_complete,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- seq orders the outbox for watch streams and is what clients resume from.
+-- Writers take an advisory lock until commit before drawing it, so rows become
+-- visible in seq order and a reader never skips one that commits late.
+CREATE SEQUENCE outbox_seq;
+
+ALTER TABLE outbox ADD COLUMN seq BIGINT;
+
+UPDATE outbox o SET seq = n.seq
+FROM (SELECT id, row_number() OVER (ORDER BY created_at, id) AS seq FROM outbox) n
+WHERE o.id = n.id;
+
+SELECT setval('outbox_seq', COALESCE((SELECT max(seq) FROM outbox), 0) + 1, false);
+
+ALTER TABLE outbox
+    ALTER COLUMN seq SET DEFAULT nextval('outbox_seq'),
+    ALTER COLUMN seq SET NOT NULL;
+ALTER SEQUENCE outbox_seq OWNED BY outbox.seq;
+
+CREATE UNIQUE INDEX outbox_seq_idx ON outbox (seq);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS outbox_seq_idx;
+ALTER TABLE outbox DROP COLUMN IF EXISTS seq;
+DROP SEQUENCE IF EXISTS outbox_seq;
+
+-- +goose StatementEnd
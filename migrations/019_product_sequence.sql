@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Products added in one batch share added_at. seq keeps their insertion order,
+-- so "the last product of a reception" is well defined.
+CREATE SEQUENCE products_seq;
+
+ALTER TABLE products ADD COLUMN seq BIGINT;
+
+UPDATE products p SET seq = n.seq
+FROM (SELECT id, row_number() OVER (ORDER BY added_at, id) AS seq FROM products) n
+WHERE p.id = n.id;
+
+SELECT setval('products_seq', COALESCE((SELECT max(seq) FROM products), 0) + 1, false);
+
+ALTER TABLE products
+    ALTER COLUMN seq SET DEFAULT nextval('products_seq'),
+    ALTER COLUMN seq SET NOT NULL;
+ALTER SEQUENCE products_seq OWNED BY products.seq;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE products DROP COLUMN IF EXISTS seq;
+DROP SEQUENCE IF EXISTS products_seq;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- An in-progress key is held only until locked_until, so a request whose
+-- process died before Complete or Release can be retried before the key expires.
+ALTER TABLE idempotency_keys ADD COLUMN locked_until TIMESTAMPTZ;
+UPDATE idempotency_keys SET locked_until = created_at WHERE NOT completed;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS locked_until;
+
+-- +goose StatementEnd
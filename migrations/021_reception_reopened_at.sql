@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- A reopened reception goes stale counting from its reopening, not from when
+-- it was first opened.
+ALTER TABLE receptions ADD COLUMN reopened_at TIMESTAMPTZ;
+UPDATE receptions r
+SET reopened_at = (
+    SELECT max(a.occurred_at) FROM audit_log a
+    WHERE a.reception_id = r.id AND a.action = 'reception_reopened'
+)
+WHERE r.status = 'reopened';
+
+DROP INDEX IF EXISTS receptions_in_progress_started_at_idx;
+CREATE INDEX receptions_open_since_idx
+    ON receptions ((COALESCE(reopened_at, started_at)))
+    WHERE status IN ('in_progress', 'reopened') AND deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS receptions_open_since_idx;
+CREATE INDEX receptions_in_progress_started_at_idx
+    ON receptions (started_at)
+    WHERE status = 'in_progress' AND deleted_at IS NULL;
+
+ALTER TABLE receptions DROP COLUMN IF EXISTS reopened_at;
+
+-- +goose StatementEnd
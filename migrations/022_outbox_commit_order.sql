@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- seq is no longer drawn by writers under a global lock. A row records the
+-- transaction that wrote it and gets its seq once every older transaction
+-- has finished, from a sequencer that holds an advisory lock; seq values thus
+-- commit in increasing order without serializing the writers.
+ALTER TABLE outbox ADD COLUMN xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE SEQUENCE outbox_insert_order;
+ALTER TABLE outbox ADD COLUMN insert_order BIGINT;
+UPDATE outbox SET insert_order = seq;
+SELECT setval('outbox_insert_order', COALESCE((SELECT max(insert_order) FROM outbox), 0) + 1, false);
+ALTER TABLE outbox
+    ALTER COLUMN insert_order SET DEFAULT nextval('outbox_insert_order'),
+    ALTER COLUMN insert_order SET NOT NULL;
+ALTER SEQUENCE outbox_insert_order OWNED BY outbox.insert_order;
+
+ALTER TABLE outbox
+    ALTER COLUMN seq DROP DEFAULT,
+    ALTER COLUMN seq DROP NOT NULL;
+
+CREATE INDEX outbox_unsequenced_idx ON outbox (xid, insert_order) WHERE seq IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS outbox_unsequenced_idx;
+
+UPDATE outbox o SET seq = s.seq
+FROM (
+    SELECT id, nextval('outbox_seq') AS seq
+    FROM (SELECT id FROM outbox WHERE seq IS NULL ORDER BY xid, insert_order) pending
+) s
+WHERE o.id = s.id;
+
+ALTER TABLE outbox
+    ALTER COLUMN seq SET DEFAULT nextval('outbox_seq'),
+    ALTER COLUMN seq SET NOT NULL;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS insert_order;
+DROP SEQUENCE IF EXISTS outbox_insert_order;
+ALTER TABLE outbox DROP COLUMN IF EXISTS xid;
+
+-- +goose StatementEnd
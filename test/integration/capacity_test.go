@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func putJSON(t *testing.T, url, token string, payload any) *http.Response {
+	t.Helper()
+
+	var body bytes.Buffer
+	require.NoError(t, json.NewEncoder(&body).Encode(payload))
+	req, err := http.NewRequest(http.MethodPut, url, &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return res
+}
+
+func TestReceptionCapacity(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := putJSON(t, ts.URL+"/pvz/"+pvzID+"/capacity", modToken, map[string]any{
+		"maxProducts": 3,
+		"maxPerType":  map[string]int{"обувь": 1},
+	})
+	requireStatus(t, res, http.StatusOK, "PUT /pvz/{id}/capacity")
+	var saved struct {
+		MaxProducts int            `json:"maxProducts"`
+		MaxPerType  map[string]int `json:"maxPerType"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&saved))
+	_ = res.Body.Close()
+	require.Equal(t, 3, saved.MaxProducts)
+	require.Equal(t, map[string]int{"shoes": 1}, saved.MaxPerType)
+
+	res = postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	add := func(productType string, want int) {
+		t.Helper()
+		res := postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": productType})
+		requireStatus(t, res, want, "POST /products "+productType)
+		_ = res.Body.Close()
+	}
+	add("обувь", http.StatusCreated)
+	add("обувь", http.StatusConflict)
+	add("одежда", http.StatusCreated)
+	add("одежда", http.StatusCreated)
+	add("одежда", http.StatusConflict)
+}
@@ -0,0 +1,117 @@
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"pvz-service/internal/domain/pvz"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type listedCity struct {
+	Name     string            `json:"name"`
+	Aliases  map[string]string `json:"aliases"`
+	Timezone string            `json:"timezone"`
+	Active   bool              `json:"active"`
+}
+
+func listCities(t *testing.T, baseURL, token string) []listedCity {
+	t.Helper()
+
+	res := get(t, baseURL+"/cities", token)
+	requireStatus(t, res, http.StatusOK, "GET /cities")
+	var cities []listedCity
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&cities))
+	_ = res.Body.Close()
+	return cities
+}
+
+func TestCityRegistryCRUD(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	clientToken := dummyToken(t, ts.URL, "employee")
+	suffix := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	name := "Тестоград " + suffix
+	alias := "Testograd " + suffix
+
+	res := postJSON(t, ts.URL+"/cities", clientToken, map[string]any{"name": name})
+	requireStatus(t, res, http.StatusForbidden, "POST /cities as employee")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/cities", modToken, map[string]any{"name": name, "timezone": "Mars/Olympus"})
+	requireStatus(t, res, http.StatusBadRequest, "POST /cities bad timezone")
+	_ = res.Body.Close()
+
+	// the lookup cache is warm and does not know the city yet
+	res = postJSON(t, ts.URL+"/pvz", modToken, map[string]any{"city": alias})
+	requireStatus(t, res, http.StatusBadRequest, "POST /pvz in an unknown city")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/cities", modToken, map[string]any{
+		"name":     " " + name + " ",
+		"aliases":  map[string]string{" EN ": " " + alias + " "},
+		"timezone": "Asia/Yekaterinburg",
+	})
+	requireStatus(t, res, http.StatusCreated, "POST /cities")
+	var created listedCity
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&created))
+	_ = res.Body.Close()
+	require.Equal(t, listedCity{
+		Name:     name,
+		Aliases:  map[string]string{"en": alias},
+		Timezone: "Asia/Yekaterinburg",
+		Active:   true,
+	}, created)
+
+	res = postJSON(t, ts.URL+"/cities", modToken, map[string]any{"name": name})
+	requireStatus(t, res, http.StatusConflict, "POST /cities duplicate name")
+	_ = res.Body.Close()
+
+	require.Contains(t, listCities(t, ts.URL, clientToken), created)
+
+	res = postJSON(t, ts.URL+"/pvz", modToken, map[string]any{"city": strings.ToLower(alias)})
+	requireStatus(t, res, http.StatusCreated, "POST /pvz in the new city")
+	var p struct {
+		City     string `json:"city"`
+		Timezone string `json:"timezone"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&p))
+	_ = res.Body.Close()
+	require.Equal(t, name, p.City)
+	require.Equal(t, "Asia/Yekaterinburg", p.Timezone)
+
+	res = deleteReq(t, ts.URL+"/cities/"+url.PathEscape(name), modToken)
+	requireStatus(t, res, http.StatusConflict, "DELETE city with PVZs")
+	_ = res.Body.Close()
+	require.Contains(t, listCities(t, ts.URL, clientToken), created)
+
+	unused := name + " 2"
+	res = postJSON(t, ts.URL+"/cities", modToken, map[string]any{"name": unused})
+	requireStatus(t, res, http.StatusCreated, "POST /cities unused")
+	_ = res.Body.Close()
+
+	res = deleteReq(t, ts.URL+"/cities/"+url.PathEscape(unused), modToken)
+	requireStatus(t, res, http.StatusNoContent, "DELETE unused city")
+	_ = res.Body.Close()
+	res = deleteReq(t, ts.URL+"/cities/"+url.PathEscape(unused), modToken)
+	requireStatus(t, res, http.StatusNotFound, "DELETE deleted city")
+	_ = res.Body.Close()
+	for _, c := range listCities(t, ts.URL, clientToken) {
+		require.NotEqual(t, unused, c.Name)
+	}
+
+	res = postJSON(t, ts.URL+"/pvz", modToken, map[string]any{"city": unused})
+	requireStatus(t, res, http.StatusBadRequest, "POST /pvz in a deleted city")
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+	require.Contains(t, string(body), pvz.ErrCityNotAllowed.Error())
+}
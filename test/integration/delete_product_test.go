@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func deleteReq(t *testing.T, url, token string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return res
+}
+
+func TestDeleteSpecificProduct(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	ids := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": "одежда"})
+		requireStatus(t, res, http.StatusCreated, "POST /products")
+		var pr struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&pr))
+		_ = res.Body.Close()
+		ids = append(ids, pr.ID)
+	}
+
+	res = deleteReq(t, ts.URL+"/pvz/"+pvzID+"/products/"+ids[0], clientToken)
+	requireStatus(t, res, http.StatusOK, "DELETE /pvz/{pvzId}/products/{productId}")
+	_ = res.Body.Close()
+
+	res = deleteReq(t, ts.URL+"/pvz/"+pvzID+"/products/"+ids[0], clientToken)
+	requireStatus(t, res, http.StatusNotFound, "DELETE already removed product")
+	_ = res.Body.Close()
+
+	res = get(t, ts.URL+"/audit?pvzId="+pvzID, modToken)
+	requireStatus(t, res, http.StatusOK, "GET /audit")
+	var entries []struct {
+		Action    string `json:"action"`
+		ActorRole string `json:"actorRole"`
+		ProductID string `json:"productId"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&entries))
+	_ = res.Body.Close()
+	removed := 0
+	for _, e := range entries {
+		if e.Action == "product_removed" {
+			removed++
+			require.Equal(t, ids[0], e.ProductID)
+			require.Equal(t, "employee", e.ActorRole)
+		}
+	}
+	require.Equal(t, 1, removed)
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/close_last_reception", clientToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{pvzId}/close_last_reception")
+	_ = res.Body.Close()
+
+	res = deleteReq(t, ts.URL+"/pvz/"+pvzID+"/products/"+ids[1], clientToken)
+	requireStatus(t, res, http.StatusBadRequest, "DELETE product of closed reception")
+	_ = res.Body.Close()
+}
@@ -0,0 +1,106 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/transport/grpc/pb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func requireCode(t *testing.T, want codes.Code, err error) {
+	t.Helper()
+	require.Error(t, err)
+	require.Equal(t, want, status.Code(err), err.Error())
+}
+
+func TestGRPCReceptionFlow(t *testing.T) {
+	env := setupGRPC(t)
+	modCtx := env.login(t, "moderator")
+	clientCtx := env.login(t, "employee")
+
+	created, err := env.pvz.CreatePVZ(modCtx, &pb.CreatePVZRequest{City: "Москва"})
+	require.NoError(t, err)
+	pvzID := created.GetId()
+
+	_, err = env.pvz.AddProduct(clientCtx, &pb.AddProductRequest{PvzId: pvzID, Type: "электроника"})
+	requireCode(t, codes.FailedPrecondition, err)
+
+	rec, err := env.pvz.OpenReception(clientCtx, &pb.OpenReceptionRequest{PvzId: pvzID})
+	require.NoError(t, err)
+	require.Equal(t, pb.ReceptionStatus_RECEPTION_STATUS_IN_PROGRESS, rec.GetStatus())
+	_, err = env.pvz.OpenReception(clientCtx, &pb.OpenReceptionRequest{PvzId: pvzID})
+	requireCode(t, codes.FailedPrecondition, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = env.pvz.AddProduct(clientCtx, &pb.AddProductRequest{PvzId: pvzID, Type: "электроника"})
+		require.NoError(t, err)
+	}
+	_, err = env.pvz.AddProduct(clientCtx, &pb.AddProductRequest{PvzId: pvzID, Type: "мебель"})
+	requireCode(t, codes.InvalidArgument, err)
+
+	_, err = env.pvz.DeleteLastProduct(clientCtx, &pb.DeleteLastProductRequest{PvzId: pvzID})
+	require.NoError(t, err)
+	_, err = env.pvz.DeleteProduct(clientCtx, &pb.DeleteProductRequest{PvzId: pvzID, ProductId: uuid.New().String()})
+	requireCode(t, codes.NotFound, err)
+
+	closed, err := env.pvz.CloseLastReception(clientCtx, &pb.CloseLastReceptionRequest{PvzId: pvzID})
+	require.NoError(t, err)
+	require.Equal(t, pb.ReceptionStatus_RECEPTION_STATUS_CLOSED, closed.GetStatus())
+	require.EqualValues(t, 2, closed.GetProductCount())
+	_, err = env.pvz.DeleteLastProduct(clientCtx, &pb.DeleteLastProductRequest{PvzId: pvzID})
+	requireCode(t, codes.FailedPrecondition, err)
+
+	reopened, err := env.pvz.ReopenReception(modCtx, &pb.ReopenReceptionRequest{ReceptionId: rec.GetId()})
+	require.NoError(t, err)
+	require.Equal(t, pb.ReceptionStatus_RECEPTION_STATUS_REOPENED, reopened.GetStatus())
+	_, err = env.pvz.CancelReception(modCtx, &pb.CancelReceptionRequest{ReceptionId: uuid.New().String()})
+	requireCode(t, codes.NotFound, err)
+
+	_, err = env.pvz.OpenReception(clientCtx, &pb.OpenReceptionRequest{PvzId: uuid.New().String()})
+	requireCode(t, codes.NotFound, err)
+	_, err = env.pvz.CreatePVZ(modCtx, &pb.CreatePVZRequest{City: "Атлантида"})
+	requireCode(t, codes.InvalidArgument, err)
+}
+
+func TestGRPCCapacityAndDuplicateBarcode(t *testing.T) {
+	env := setupGRPC(t)
+	modCtx := env.login(t, "moderator")
+	clientCtx := env.login(t, "employee")
+
+	created, err := env.pvz.CreatePVZ(modCtx, &pb.CreatePVZRequest{City: "Казань"})
+	require.NoError(t, err)
+	pvzID := created.GetId()
+	require.NoError(t, env.db.PVZRepo().SetCapacity(context.Background(), pvzID, pvz.Capacity{MaxProducts: 2}))
+
+	_, err = env.pvz.OpenReception(clientCtx, &pb.OpenReceptionRequest{PvzId: pvzID})
+	require.NoError(t, err)
+
+	barcode := "BC-" + uuid.New().String()[:8]
+	_, err = env.pvz.AddProduct(clientCtx, &pb.AddProductRequest{PvzId: pvzID, Type: "обувь", Barcode: barcode})
+	require.NoError(t, err)
+	_, err = env.pvz.AddProduct(clientCtx, &pb.AddProductRequest{PvzId: pvzID, Type: "обувь", Barcode: barcode})
+	requireCode(t, codes.AlreadyExists, err)
+
+	_, err = env.pvz.AddProduct(clientCtx, &pb.AddProductRequest{PvzId: pvzID, Type: "обувь"})
+	require.NoError(t, err)
+	_, err = env.pvz.AddProduct(clientCtx, &pb.AddProductRequest{PvzId: pvzID, Type: "обувь"})
+	requireCode(t, codes.ResourceExhausted, err)
+}
+
+func TestGRPCPVZListPageSize(t *testing.T) {
+	env := setupGRPC(t)
+	clientCtx := env.login(t, "employee")
+
+	list, err := env.pvz.GetPVZList(clientCtx, &pb.GetPVZListRequest{Limit: 30})
+	require.NoError(t, err)
+	require.LessOrEqual(t, len(list.GetPvzs()), 30)
+
+	_, err = env.pvz.GetPVZList(clientCtx, &pb.GetPVZListRequest{Limit: 31})
+	requireCode(t, codes.InvalidArgument, err)
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"pvz-service/internal/adapter/auth/jwt"
+	"pvz-service/internal/adapter/auth/password"
+	"pvz-service/internal/adapter/db/postgres"
+	"pvz-service/internal/adapter/eventbus"
+	clockad "pvz-service/internal/adapter/time"
+	"pvz-service/internal/config"
+	"pvz-service/internal/transport/grpc/handler"
+	"pvz-service/internal/transport/grpc/interceptor"
+	"pvz-service/internal/transport/grpc/pb"
+	"pvz-service/internal/usecase/auth"
+	catalogUC "pvz-service/internal/usecase/catalog"
+	cityUC "pvz-service/internal/usecase/city"
+	idempotencyUC "pvz-service/internal/usecase/idempotency"
+	pvzUC "pvz-service/internal/usecase/pvz"
+	receptionUC "pvz-service/internal/usecase/reception"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type grpcEnv struct {
+	db   *postgres.PostgresDB
+	pvz  pb.PVZServiceClient
+	auth pb.AuthServiceClient
+}
+
+// setupGRPC serves the gRPC API over an in-memory listener, wired as in
+// cmd/pvz-grpc. Every call starts a separate server process as far as the
+// watch streams are concerned.
+func setupGRPC(t *testing.T) *grpcEnv {
+	t.Helper()
+
+	cfg := config.Load()
+	db, err := postgres.NewDB(cfg.DB.Host, 15433, cfg.DB.User, cfg.DB.Password, cfg.DB.Name)
+	require.NoError(t, err)
+	require.NoError(t, db.Ping(context.Background()))
+
+	tokenManager := jwt.NewTokenManagerJWT(cfg.JWT.Secret)
+	clock := clockad.RealClock{}
+	catalogService := catalogUC.NewService(db.ProductTypeRepo(), clock, catalogUC.DefaultCacheTTL)
+	pvzService := pvzUC.NewService(db, db.PVZReadModel(), cityUC.NewService(db.CityRepo(), clock, cityUC.DefaultCacheTTL), clock)
+	receptionService := receptionUC.NewService(db, catalogService, clock, receptionUC.DefaultReopenWindow)
+
+	ctx, stop := context.WithCancel(context.Background())
+	feed := eventbus.NewFeed(db.OutboxRepo(), eventbus.FeedConfig{
+		PollInterval: 20 * time.Millisecond,
+		BatchSize:    100,
+		HistorySize:  eventbus.DefaultHistorySize,
+	})
+	require.NoError(t, feed.Start(ctx))
+	go feed.Run(ctx)
+
+	authInterceptor := interceptor.NewAuthInterceptor(tokenManager, interceptor.PublicMethods, interceptor.MethodRoles)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			authInterceptor.Unary(),
+			interceptor.NewIdempotencyInterceptor(idempotencyUC.NewService(db.IdempotencyRepo(), clock, idempotencyUC.DefaultTTL, idempotencyUC.DefaultLease), interceptor.IdempotentMethods).Unary(),
+		),
+		grpc.StreamInterceptor(authInterceptor.Stream()),
+	)
+	pb.RegisterPVZServiceServer(srv, handler.NewPVZServer(pvzService, receptionService, catalogService, feed))
+	pb.RegisterAuthServiceServer(srv, handler.NewAuthServer(auth.NewService(db.UserRepo(), tokenManager, password.NewHasher(), clock)))
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		srv.Stop()
+		stop()
+		db.Close()
+	})
+	return &grpcEnv{db: db, pvz: pb.NewPVZServiceClient(conn), auth: pb.NewAuthServiceClient(conn)}
+}
+
+func (e *grpcEnv) login(t *testing.T, role string) context.Context {
+	t.Helper()
+
+	res, err := e.auth.DummyLogin(context.Background(), &pb.DummyLoginRequest{Role: role})
+	require.NoError(t, err)
+	return withToken(context.Background(), res.GetToken())
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
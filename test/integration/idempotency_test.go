@@ -0,0 +1,170 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"pvz-service/internal/adapter/auth/jwt"
+	"pvz-service/internal/adapter/db/postgres"
+	"pvz-service/internal/config"
+	domain "pvz-service/internal/domain/idempotency"
+	"pvz-service/internal/domain/user"
+	idempotencyUC "pvz-service/internal/usecase/idempotency"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func postJSONWithKey(t *testing.T, url, token, key string, payload any) *http.Response {
+	t.Helper()
+
+	var body bytes.Buffer
+	require.NoError(t, json.NewEncoder(&body).Encode(payload))
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", key)
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return res
+}
+
+func TestIdempotentAddProduct(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	key := uuid.NewString()
+	add := func() (string, string) {
+		t.Helper()
+		res := postJSONWithKey(t, ts.URL+"/products", clientToken, key, map[string]any{"pvzId": pvzID, "type": "обувь"})
+		requireStatus(t, res, http.StatusCreated, "POST /products")
+		defer res.Body.Close()
+		var pr struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&pr))
+		return pr.ID, res.Header.Get("Idempotent-Replayed")
+	}
+	firstID, replayed := add()
+	require.Empty(t, replayed)
+	secondID, replayed := add()
+	require.Equal(t, firstID, secondID)
+	require.Equal(t, "true", replayed)
+
+	res = postJSONWithKey(t, ts.URL+"/products", clientToken, key, map[string]any{"pvzId": pvzID, "type": "одежда"})
+	requireStatus(t, res, http.StatusUnprocessableEntity, "POST /products with reused key")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/close_last_reception", clientToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	var closed struct {
+		ProductCount int `json:"productCount"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&closed))
+	_ = res.Body.Close()
+	require.Equal(t, 1, closed.ProductCount)
+}
+
+func TestIdempotencyKeysAreScopedToCaller(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	firstToken := dummyToken(t, ts.URL, "employee")
+	secondToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", firstToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	key := uuid.NewString()
+	payload := map[string]any{"pvzId": pvzID, "type": "обувь"}
+	add := func(token string) (string, string) {
+		t.Helper()
+		res := postJSONWithKey(t, ts.URL+"/products", token, key, payload)
+		requireStatus(t, res, http.StatusCreated, "POST /products")
+		defer res.Body.Close()
+		var pr struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&pr))
+		return pr.ID, res.Header.Get("Idempotent-Replayed")
+	}
+	firstID, _ := add(firstToken)
+	secondID, replayed := add(secondToken)
+	require.Empty(t, replayed, "another employee's key is not replayed")
+	require.NotEqual(t, firstID, secondID)
+
+	// the role is checked before a stored response is looked up
+	res = postJSONWithKey(t, ts.URL+"/products", modToken, key, payload)
+	requireStatus(t, res, http.StatusForbidden, "POST /products as moderator with a used key")
+	require.Empty(t, res.Header.Get("Idempotent-Replayed"))
+	_ = res.Body.Close()
+
+	anonymous, err := jwt.NewTokenManagerJWT(config.Load().JWT.Secret).GenerateToken(&user.User{Role: user.RoleClient})
+	require.NoError(t, err)
+	res = postJSONWithKey(t, ts.URL+"/products", anonymous, key, payload)
+	requireStatus(t, res, http.StatusBadRequest, "POST /products with a key and no user id")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/close_last_reception", firstToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	var closed struct {
+		ProductCount int `json:"productCount"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&closed))
+	_ = res.Body.Close()
+	require.Equal(t, 2, closed.ProductCount)
+}
+
+func TestIdempotencyLeaseTakeover(t *testing.T) {
+	cfg := config.Load()
+	db, err := postgres.NewDB(cfg.DB.Host, 15433, cfg.DB.User, cfg.DB.Password, cfg.DB.Name)
+	require.NoError(t, err)
+	require.NoError(t, db.Ping(context.Background()))
+	t.Cleanup(db.Close)
+
+	ctx := context.Background()
+	scope, key := "user-"+uuid.New().String(), uuid.New().String()
+	at := func(now time.Time) *idempotencyUC.Service {
+		return idempotencyUC.NewService(db.IdempotencyRepo(), fixedClock{now: now}, time.Hour, time.Minute)
+	}
+	start := time.Now()
+
+	// the first attempt reserves the key and its process dies before Complete
+	rec, err := at(start).Begin(ctx, scope, key, "payload")
+	require.NoError(t, err)
+	require.Nil(t, rec)
+
+	_, err = at(start.Add(30*time.Second)).Begin(ctx, scope, key, "payload")
+	require.ErrorIs(t, err, domain.ErrRequestInProgress, "the lease is still held")
+
+	_, err = at(start.Add(2*time.Minute)).Begin(ctx, scope, key, "other payload")
+	require.ErrorIs(t, err, domain.ErrKeyReused, "an abandoned key is not handed to another request")
+
+	retry := at(start.Add(2 * time.Minute))
+	rec, err = retry.Begin(ctx, scope, key, "payload")
+	require.NoError(t, err, "a retry takes over once the lease has run out")
+	require.Nil(t, rec)
+	require.NoError(t, retry.Complete(ctx, scope, key, http.StatusCreated, "application/json", []byte(`{}`)))
+
+	rec, err = at(start.Add(10*time.Minute)).Begin(ctx, scope, key, "payload")
+	require.NoError(t, err)
+	require.NotNil(t, rec, "a completed key is replayed, whatever its lease")
+	require.Equal(t, http.StatusCreated, rec.StatusCode)
+}
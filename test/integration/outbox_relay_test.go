@@ -0,0 +1,144 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"pvz-service/internal/adapter/db/repo"
+	clockad "pvz-service/internal/adapter/time"
+	"pvz-service/internal/domain/event"
+	outboxUC "pvz-service/internal/usecase/outbox"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	seen   map[string]int
+	failID string
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, e event.DomainEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.seen[e.ID]++
+	if e.ID == p.failID {
+		return errors.New("publisher unavailable")
+	}
+	return nil
+}
+
+func TestOutboxRelaySkipsUndecodableMessages(t *testing.T) {
+	pool := openPool(t)
+	defer pool.Close()
+	ctx := context.Background()
+	outboxRepo := repo.NewOutboxRepo(pool)
+	now := time.Now().UTC()
+
+	// Битая запись старше остальных: раньше она останавливала всю очередь
+	poisonID := uuid.New().String()
+	_, err := pool.Exec(ctx,
+		`INSERT INTO outbox(id, event_type, payload, status, created_at, next_attempt_at)
+		VALUES($1, 'product_added', '{"occurredAt": "not a time"}', 'pending', $2, $2)`,
+		poisonID, now.Add(-time.Minute))
+	require.NoError(t, err)
+
+	good := event.DomainEvent{ID: uuid.New().String(), Type: event.PVZCreated, PVZID: uuid.New().String(), OccurredAt: now}
+	failing := event.DomainEvent{ID: uuid.New().String(), Type: event.PVZCreated, PVZID: uuid.New().String(), OccurredAt: now}
+	require.NoError(t, outboxRepo.Add(ctx, good))
+	require.NoError(t, outboxRepo.Add(ctx, failing))
+
+	publisher := &recordingPublisher{seen: map[string]int{}, failID: failing.ID}
+	relay := outboxUC.NewRelay(outboxRepo, publisher, clockad.RealClock{}, outboxUC.Config{
+		PollInterval: time.Second,
+		Lease:        time.Minute,
+		BatchSize:    100,
+		MaxAttempts:  3,
+		BaseBackoff:  time.Hour,
+		MaxBackoff:   time.Hour,
+	})
+	for {
+		n, err := relay.ProcessBatch(ctx)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+
+	require.Equal(t, 1, publisher.seen[good.ID])
+	require.Equal(t, 1, publisher.seen[failing.ID], "a failed message waits for its backoff")
+	require.Zero(t, publisher.seen[poisonID])
+
+	type row struct {
+		status    string
+		attempts  int
+		lastError *string
+	}
+	load := func(id string) row {
+		var r row
+		require.NoError(t, pool.QueryRow(ctx,
+			"SELECT status, attempts, last_error FROM outbox WHERE id=$1", id).Scan(&r.status, &r.attempts, &r.lastError))
+		return r
+	}
+
+	poison := load(poisonID)
+	require.Equal(t, "dead", poison.status)
+	require.NotNil(t, poison.lastError)
+	require.Equal(t, "delivered", load(good.ID).status)
+	failed := load(failing.ID)
+	require.Equal(t, "pending", failed.status)
+	require.Equal(t, 1, failed.attempts)
+}
+
+func TestOutboxWritersDoNotSerialize(t *testing.T) {
+	pool := openPool(t)
+	defer pool.Close()
+	ctx := context.Background()
+	log := repo.NewOutboxRepo(pool)
+	start, err := log.LastSequence(ctx)
+	require.NoError(t, err)
+
+	newEvent := func() event.DomainEvent {
+		return event.DomainEvent{ID: uuid.New().String(), Type: event.PVZCreated, PVZID: uuid.New().String(), OccurredAt: time.Now().UTC()}
+	}
+	ours := func() []string {
+		t.Helper()
+		msgs, err := log.ListAfter(ctx, start, 1000)
+		require.NoError(t, err)
+		var ids []string
+		last := start
+		for _, m := range msgs {
+			require.Greater(t, m.Sequence, last)
+			last = m.Sequence
+			ids = append(ids, m.ID)
+		}
+		return ids
+	}
+
+	first, second := newEvent(), newEvent()
+	tx1, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx1.Rollback(ctx) }()
+	require.NoError(t, repo.NewOutboxRepo(tx1).Add(ctx, first))
+
+	// a writer in another PVZ does not wait for the first one to commit
+	tx2, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx2.Rollback(ctx) }()
+	addCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	require.NoError(t, repo.NewOutboxRepo(tx2).Add(addCtx, second))
+	require.NoError(t, tx2.Commit(ctx))
+
+	// but its event is not numbered while an older writer may still commit
+	require.NotContains(t, ours(), second.ID)
+
+	require.NoError(t, tx1.Commit(ctx))
+	ids := ours()
+	require.Contains(t, ids, first.ID)
+	require.Contains(t, ids, second.ID)
+}
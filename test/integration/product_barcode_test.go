@@ -0,0 +1,97 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductBarcodeLookup(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	pvzID := createPVZ(t, ts.URL, dummyToken(t, ts.URL, "moderator"), "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	var opened struct {
+		ID       string `json:"id"`
+		OpenedBy string `json:"openedBy"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&opened))
+	_ = res.Body.Close()
+
+	barcode := "BC-" + uuid.New().String()[:8]
+	item := map[string]any{
+		"pvzId":                pvzID,
+		"type":                 "электроника",
+		"barcode":              barcode,
+		"weightGrams":          1200,
+		"declaredValueKopecks": 1999900,
+	}
+	res = postJSON(t, ts.URL+"/products", clientToken, item)
+	requireStatus(t, res, http.StatusCreated, "POST /products (scanned)")
+	var added struct {
+		ID          string `json:"id"`
+		Barcode     string `json:"barcode"`
+		WeightGrams int    `json:"weightGrams"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&added))
+	_ = res.Body.Close()
+	require.Equal(t, barcode, added.Barcode)
+	require.Equal(t, 1200, added.WeightGrams)
+
+	res = postJSON(t, ts.URL+"/products", clientToken, item)
+	requireStatus(t, res, http.StatusConflict, "POST /products (duplicate barcode)")
+	_ = res.Body.Close()
+
+	var found []struct {
+		Product struct {
+			ID string `json:"id"`
+		} `json:"product"`
+		Reception struct {
+			ID           string     `json:"id"`
+			PVZID        string     `json:"pvzId"`
+			Status       string     `json:"status"`
+			ClosedAt     *time.Time `json:"closedAt"`
+			OpenedBy     string     `json:"openedBy"`
+			ClosedBy     string     `json:"closedBy"`
+			ProductCount int        `json:"productCount"`
+		} `json:"reception"`
+	}
+	lookup := func() {
+		t.Helper()
+		res := get(t, ts.URL+"/products/by-barcode/"+barcode, clientToken)
+		requireStatus(t, res, http.StatusOK, "GET /products/by-barcode")
+		found = nil
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&found))
+		_ = res.Body.Close()
+		require.Len(t, found, 1)
+		require.Equal(t, added.ID, found[0].Product.ID)
+		require.Equal(t, opened.ID, found[0].Reception.ID)
+		require.Equal(t, pvzID, found[0].Reception.PVZID)
+		require.Equal(t, opened.OpenedBy, found[0].Reception.OpenedBy)
+	}
+	lookup()
+	require.Equal(t, "in_progress", found[0].Reception.Status)
+	require.Nil(t, found[0].Reception.ClosedAt)
+	require.Equal(t, 1, found[0].Reception.ProductCount)
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/close_last_reception", clientToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	_ = res.Body.Close()
+	lookup()
+	require.Equal(t, "close", found[0].Reception.Status)
+	require.NotNil(t, found[0].Reception.ClosedAt)
+	require.NotEmpty(t, found[0].Reception.ClosedBy)
+	require.Equal(t, 1, found[0].Reception.ProductCount)
+
+	res = get(t, ts.URL+"/products/by-barcode/UNKNOWN-"+uuid.New().String()[:8], clientToken)
+	requireStatus(t, res, http.StatusNotFound, "GET /products/by-barcode (unknown)")
+	_ = res.Body.Close()
+}
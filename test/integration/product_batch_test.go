@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type batchResponse struct {
+	Added int `json:"added"`
+	Items []struct {
+		Index  int    `json:"index"`
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	} `json:"items"`
+}
+
+func postBatch(t *testing.T, url, token string, payload any, want int) batchResponse {
+	t.Helper()
+
+	res := postJSON(t, url+"/products/batch", token, payload)
+	requireStatus(t, res, want, "POST /products/batch")
+	defer res.Body.Close()
+	var out batchResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&out))
+	return out
+}
+
+func TestProductBatch(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	items := []map[string]any{
+		{"type": "электроника", "barcode": "4600000000011"},
+		{"type": "неизвестный"},
+		{"type": "одежда", "barcode": "4600000000011"},
+		{"type": "обувь"},
+	}
+
+	out := postBatch(t, ts.URL, clientToken, map[string]any{"pvzId": pvzID, "items": items}, http.StatusUnprocessableEntity)
+	require.Zero(t, out.Added)
+	require.Len(t, out.Items, 4)
+	require.Equal(t, "failed", out.Items[1].Status)
+
+	out = postBatch(t, ts.URL, clientToken, map[string]any{"pvzId": pvzID, "mode": "best_effort", "items": items}, http.StatusOK)
+	require.Equal(t, 2, out.Added)
+	require.Equal(t, "added", out.Items[0].Status)
+	require.Equal(t, "failed", out.Items[1].Status)
+	require.Equal(t, "failed", out.Items[2].Status)
+	require.Equal(t, "added", out.Items[3].Status)
+
+	out = postBatch(t, ts.URL, clientToken, map[string]any{"pvzId": pvzID, "items": []map[string]any{{"type": "обувь"}}}, http.StatusCreated)
+	require.Equal(t, 1, out.Added)
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/close_last_reception", clientToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	var closed struct {
+		ProductCount int `json:"productCount"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&closed))
+	_ = res.Body.Close()
+	require.Equal(t, 3, closed.ProductCount)
+
+	// every accepted item is audited on its own, none of the rejected ones
+	products := map[string]bool{}
+	for _, e := range listAudit(t, ts.URL, modToken, url.Values{"pvzId": {pvzID}}) {
+		if e.Action == "product_added" {
+			require.Equal(t, "employee", e.ActorRole)
+			require.NotEmpty(t, e.ReceptionID)
+			products[e.ProductID] = true
+		}
+	}
+	require.Len(t, products, 3)
+}
+
+func TestDeleteLastProductAfterBatch(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	// every item of a batch is added at the same instant
+	prefix := "46" + strconv.FormatInt(time.Now().UnixNano()%1e9, 10)
+	var barcodes []string
+	var items []map[string]any
+	for i := 0; i < 5; i++ {
+		barcodes = append(barcodes, prefix+strconv.Itoa(i))
+		items = append(items, map[string]any{"type": "обувь", "barcode": barcodes[i]})
+	}
+	out := postBatch(t, ts.URL, clientToken, map[string]any{"pvzId": pvzID, "items": items}, http.StatusCreated)
+	require.Equal(t, 5, out.Added)
+
+	for i := 0; i < 2; i++ {
+		res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/delete_last_product", clientToken, nil)
+		requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/delete_last_product")
+		_ = res.Body.Close()
+	}
+
+	for i, barcode := range barcodes {
+		res = get(t, ts.URL+"/products/by-barcode/"+barcode, clientToken)
+		want := http.StatusOK
+		if i >= 3 {
+			want = http.StatusNotFound
+		}
+		requireStatus(t, res, want, "GET /products/by-barcode/"+barcode)
+		_ = res.Body.Close()
+	}
+}
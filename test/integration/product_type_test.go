@@ -0,0 +1,141 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type listedProductType struct {
+	Code   string            `json:"code"`
+	Names  map[string]string `json:"names"`
+	Active bool              `json:"active"`
+}
+
+func TestProductTypeCatalogCRUD(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	clientToken := dummyToken(t, ts.URL, "employee")
+	suffix := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	code := "t_" + suffix
+	name := "Тестовый " + suffix
+
+	res := postJSON(t, ts.URL+"/product-types", clientToken, map[string]any{"code": code})
+	requireStatus(t, res, http.StatusForbidden, "POST /product-types as employee")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/product-types", modToken, map[string]any{"code": "Bad-Code"})
+	requireStatus(t, res, http.StatusBadRequest, "POST /product-types bad code")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/product-types", modToken, map[string]any{
+		"code":  code,
+		"names": map[string]string{" RU ": " " + name + " "},
+	})
+	requireStatus(t, res, http.StatusCreated, "POST /product-types")
+	var created listedProductType
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&created))
+	_ = res.Body.Close()
+	require.Equal(t, listedProductType{Code: code, Names: map[string]string{"ru": name}, Active: true}, created)
+
+	res = postJSON(t, ts.URL+"/product-types", modToken, map[string]any{"code": code})
+	requireStatus(t, res, http.StatusConflict, "POST /product-types duplicate")
+	_ = res.Body.Close()
+
+	res = get(t, ts.URL+"/product-types", clientToken)
+	requireStatus(t, res, http.StatusOK, "GET /product-types")
+	var listed []listedProductType
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&listed))
+	_ = res.Body.Close()
+	require.Contains(t, listed, created)
+
+	res = patchJSON(t, ts.URL+"/product-types/"+code, modToken, map[string]any{
+		"names": map[string]string{"ru": name, "en": "Test " + suffix},
+	})
+	requireStatus(t, res, http.StatusOK, "PATCH /product-types/{code}")
+	var updated listedProductType
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&updated))
+	_ = res.Body.Close()
+	require.Equal(t, map[string]string{"ru": name, "en": "Test " + suffix}, updated.Names)
+	require.True(t, updated.Active, "a patch without active keeps the flag")
+
+	res = patchJSON(t, ts.URL+"/product-types/missing_"+suffix, modToken, map[string]any{"active": false})
+	requireStatus(t, res, http.StatusNotFound, "PATCH unknown type")
+	_ = res.Body.Close()
+
+	res = deleteReq(t, ts.URL+"/product-types/"+code, modToken)
+	requireStatus(t, res, http.StatusNoContent, "DELETE unused type")
+	_ = res.Body.Close()
+	res = deleteReq(t, ts.URL+"/product-types/"+code, modToken)
+	requireStatus(t, res, http.StatusNotFound, "DELETE deleted type")
+	_ = res.Body.Close()
+}
+
+func TestProductTypeChangesApplyToReceptions(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+	suffix := strings.ReplaceAll(uuid.New().String(), "-", "")[:8]
+	code := "t_" + suffix
+	name := "Тестовый " + suffix
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	add := func(productType string, want int) {
+		t.Helper()
+		res := postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": productType})
+		requireStatus(t, res, want, "POST /products "+productType)
+		if want == http.StatusCreated {
+			var added struct {
+				Type string `json:"type"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&added))
+			require.Equal(t, name, added.Type)
+		}
+		_ = res.Body.Close()
+	}
+
+	// the catalog is cached, but a change made through the service is seen at once
+	add(name, http.StatusBadRequest)
+	res = postJSON(t, ts.URL+"/product-types", modToken, map[string]any{
+		"code":  code,
+		"names": map[string]string{"ru": name},
+	})
+	requireStatus(t, res, http.StatusCreated, "POST /product-types")
+	_ = res.Body.Close()
+	add(name, http.StatusCreated)
+	add(code, http.StatusCreated)
+
+	res = patchJSON(t, ts.URL+"/product-types/"+code, modToken, map[string]any{"active": false})
+	requireStatus(t, res, http.StatusOK, "PATCH /product-types/{code} deactivate")
+	_ = res.Body.Close()
+	add(name, http.StatusBadRequest)
+	add(code, http.StatusBadRequest)
+
+	res = deleteReq(t, ts.URL+"/product-types/"+code, modToken)
+	requireStatus(t, res, http.StatusConflict, "DELETE type in use")
+	_ = res.Body.Close()
+
+	res = get(t, ts.URL+"/pvz/"+pvzID, clientToken)
+	requireStatus(t, res, http.StatusOK, "GET /pvz/{id}")
+	var details struct {
+		Receptions []struct {
+			ProductCount int `json:"productCount"`
+		} `json:"receptions"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&details))
+	_ = res.Body.Close()
+	require.Len(t, details.Receptions, 1)
+	require.Equal(t, 2, details.Receptions[0].ProductCount, "deactivating a type keeps products already accepted")
+}
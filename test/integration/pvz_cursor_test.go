@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPVZListCursorPagination(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	since := time.Now().UTC().Add(-time.Millisecond)
+
+	var created []string
+	for i := 0; i < 3; i++ {
+		created = append(created, createPVZ(t, ts.URL, modToken, "Казань"))
+	}
+
+	page := func(cursor string) ([]string, string) {
+		t.Helper()
+		params := url.Values{"dateFilter": {"pvz"}, "startDate": {stamp(since)}, "limit": {"2"}}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		res := get(t, ts.URL+"/pvz?"+params.Encode(), modToken)
+		requireStatus(t, res, http.StatusOK, "GET /pvz?"+params.Encode())
+		defer res.Body.Close()
+		var items []listedPVZ
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&items))
+		ids := make([]string, 0, len(items))
+		for _, it := range items {
+			ids = append(ids, it.PVZ.ID)
+		}
+		return ids, res.Header.Get("X-Next-Cursor")
+	}
+
+	seen, cursor := page("")
+	require.Equal(t, created[:2], seen)
+	require.NotEmpty(t, cursor)
+
+	// PVZs registered between pages neither shift nor repeat what is left
+	for i := 0; i < 2; i++ {
+		created = append(created, createPVZ(t, ts.URL, modToken, "Казань"))
+	}
+	for cursor != "" {
+		var ids []string
+		ids, cursor = page(cursor)
+		seen = append(seen, ids...)
+	}
+	require.Equal(t, created, seen)
+
+	for _, bad := range []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("garbage")),
+		base64.RawURLEncoding.EncodeToString([]byte("yesterday|" + created[0])),
+		base64.RawURLEncoding.EncodeToString([]byte(time.Now().UTC().Format(time.RFC3339Nano) + "|not-a-uuid")),
+	} {
+		res := get(t, ts.URL+"/pvz?"+url.Values{"cursor": {bad}}.Encode(), modToken)
+		requireStatus(t, res, http.StatusBadRequest, "GET /pvz with cursor "+bad)
+		_ = res.Body.Close()
+	}
+}
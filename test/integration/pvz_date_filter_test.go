@@ -0,0 +1,157 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func listPVZ(t *testing.T, baseURL, token string, params url.Values) []listedPVZ {
+	t.Helper()
+	params.Set("limit", "30")
+	res := get(t, baseURL+"/pvz?"+params.Encode(), token)
+	requireStatus(t, res, http.StatusOK, "GET /pvz?"+params.Encode())
+	defer res.Body.Close()
+	var items []listedPVZ
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&items))
+	return items
+}
+
+// stamp matches the microsecond precision the database keeps.
+func stamp(t time.Time) string {
+	return t.Truncate(time.Microsecond).Format(time.RFC3339Nano)
+}
+
+func TestPVZListDateFilterModes(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	createdAt := func() (string, time.Time) {
+		t.Helper()
+		res := postJSON(t, ts.URL+"/pvz", modToken, map[string]any{"city": "Казань"})
+		requireStatus(t, res, http.StatusCreated, "POST /pvz")
+		var p struct {
+			ID               string    `json:"id"`
+			RegistrationDate time.Time `json:"registrationDate"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&p))
+		_ = res.Body.Close()
+		return p.ID, p.RegistrationDate
+	}
+	pvzA, regA := createdAt()
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzA})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	var rec struct {
+		ID       string    `json:"id"`
+		DateTime time.Time `json:"dateTime"`
+		OpenedBy string    `json:"openedBy"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&rec))
+	_ = res.Body.Close()
+
+	var productIDs []string
+	var addedAt []time.Time
+	for i := 0; i < 3; i++ {
+		time.Sleep(5 * time.Millisecond)
+		res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzA, "type": "обувь"})
+		requireStatus(t, res, http.StatusCreated, "POST /products")
+		var pr struct {
+			ID       string    `json:"id"`
+			DateTime time.Time `json:"dateTime"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&pr))
+		_ = res.Body.Close()
+		productIDs = append(productIDs, pr.ID)
+		addedAt = append(addedAt, pr.DateTime)
+	}
+	time.Sleep(5 * time.Millisecond)
+	pvzB, regB := createdAt()
+
+	// by product time, bounds inclusive: only the products in range are listed
+	items := listPVZ(t, ts.URL, modToken, url.Values{
+		"dateFilter": {"product"}, "openedBy": {rec.OpenedBy},
+		"startDate": {stamp(addedAt[1])}, "endDate": {stamp(addedAt[1])},
+	})
+	require.Len(t, items, 1)
+	require.Len(t, items[0].Receptions, 1)
+	require.Len(t, items[0].Receptions[0].Products, 1)
+	require.Equal(t, productIDs[1], items[0].Receptions[0].Products[0].ID)
+	require.Equal(t, 3, items[0].Receptions[0].Reception.ProductCount, "the count covers the whole reception")
+
+	items = listPVZ(t, ts.URL, modToken, url.Values{
+		"dateFilter": {"product"}, "openedBy": {rec.OpenedBy},
+		"startDate": {stamp(addedAt[1])}, "endDate": {stamp(addedAt[2])},
+	})
+	require.Len(t, items, 1)
+	require.Len(t, items[0].Receptions[0].Products, 2)
+
+	items = listPVZ(t, ts.URL, modToken, url.Values{
+		"dateFilter": {"product"}, "openedBy": {rec.OpenedBy},
+		"startDate": {stamp(addedAt[2].Add(time.Microsecond))},
+	})
+	require.Empty(t, items)
+
+	// by reception start: the reception keeps all its products
+	items = listPVZ(t, ts.URL, modToken, url.Values{
+		"openedBy":  {rec.OpenedBy},
+		"startDate": {stamp(rec.DateTime)}, "endDate": {stamp(rec.DateTime)},
+	})
+	require.Len(t, items, 1)
+	require.Len(t, items[0].Receptions[0].Products, 3)
+	require.Equal(t, 3, items[0].Receptions[0].Reception.ProductCount)
+
+	items = listPVZ(t, ts.URL, modToken, url.Values{
+		"openedBy":  {rec.OpenedBy},
+		"startDate": {stamp(rec.DateTime.Add(time.Microsecond))},
+	})
+	require.Empty(t, items)
+
+	// by PVZ registration: PVZs without receptions are listed as well
+	ids := func(items []listedPVZ) []string {
+		out := make([]string, 0, len(items))
+		for _, it := range items {
+			out = append(out, it.PVZ.ID)
+		}
+		return out
+	}
+	byRegistration := url.Values{"dateFilter": {"pvz"}, "startDate": {stamp(regA)}, "endDate": {stamp(regB)}}
+	items = listPVZ(t, ts.URL, modToken, byRegistration)
+	require.Equal(t, []string{pvzA, pvzB}, ids(items))
+	require.Len(t, items[0].Receptions[0].Products, 3)
+
+	items = listPVZ(t, ts.URL, modToken, url.Values{"dateFilter": {"pvz"}, "startDate": {stamp(regA)}, "endDate": {stamp(regA)}})
+	require.Equal(t, []string{pvzA}, ids(items))
+
+	// no closed receptions here: nothing unless empty PVZs are asked for
+	byRegistration.Set("receptionStatus", "close")
+	require.Empty(t, listPVZ(t, ts.URL, modToken, byRegistration))
+	byRegistration.Set("includeEmpty", "true")
+	items = listPVZ(t, ts.URL, modToken, byRegistration)
+	require.Equal(t, []string{pvzA, pvzB}, ids(items))
+	require.Empty(t, items[0].Receptions)
+	require.Empty(t, items[1].Receptions)
+
+	// closed, the stored total is reported the same way
+	res = postJSON(t, ts.URL+"/pvz/"+pvzA+"/close_last_reception", clientToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	_ = res.Body.Close()
+	items = listPVZ(t, ts.URL, modToken, url.Values{
+		"dateFilter": {"product"}, "openedBy": {rec.OpenedBy},
+		"startDate": {stamp(addedAt[1])}, "endDate": {stamp(addedAt[1])},
+	})
+	require.Len(t, items, 1)
+	require.Len(t, items[0].Receptions[0].Products, 1)
+	require.Equal(t, 3, items[0].Receptions[0].Reception.ProductCount)
+
+	res = get(t, ts.URL+"/pvz?dateFilter=closing", modToken)
+	requireStatus(t, res, http.StatusBadRequest, "GET /pvz with unknown dateFilter")
+	_ = res.Body.Close()
+}
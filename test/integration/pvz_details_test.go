@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func patchJSON(t *testing.T, url, token string, payload any) *http.Response {
+	t.Helper()
+
+	var body bytes.Buffer
+	require.NoError(t, json.NewEncoder(&body).Encode(payload))
+	req, err := http.NewRequest(http.MethodPatch, url, &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return res
+}
+
+func TestPVZDetailsAndDecommission(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	var opened struct {
+		ID       string `json:"id"`
+		OpenedBy string `json:"openedBy"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&opened))
+	_ = res.Body.Close()
+	for i := 0; i < 2; i++ {
+		res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": "обувь"})
+		requireStatus(t, res, http.StatusCreated, "POST /products")
+		_ = res.Body.Close()
+	}
+
+	res = get(t, ts.URL+"/pvz/"+pvzID, clientToken)
+	requireStatus(t, res, http.StatusOK, "GET /pvz/{id}")
+	var details struct {
+		ReceptionCount int `json:"receptionCount"`
+		ProductCount   int `json:"productCount"`
+		Receptions     []struct {
+			ProductCount int `json:"productCount"`
+		} `json:"receptions"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&details))
+	_ = res.Body.Close()
+	require.Equal(t, 1, details.ReceptionCount)
+	require.Equal(t, 2, details.ProductCount)
+	require.Len(t, details.Receptions, 1)
+	require.Equal(t, 2, details.Receptions[0].ProductCount)
+
+	res = patchJSON(t, ts.URL+"/pvz/"+pvzID, modToken, map[string]any{
+		"name":         "ПВЗ на Тверской",
+		"address":      "ул. Тверская, 1",
+		"workingHours": map[string]any{"mon": map[string]string{"open": "09:00", "close": "21:00"}},
+	})
+	requireStatus(t, res, http.StatusOK, "PATCH /pvz/{id}")
+	var updated struct {
+		Name         string `json:"name"`
+		Address      string `json:"address"`
+		WorkingHours map[string]struct {
+			Open string `json:"open"`
+		} `json:"workingHours"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&updated))
+	_ = res.Body.Close()
+	require.Equal(t, "ПВЗ на Тверской", updated.Name)
+	require.Equal(t, "ул. Тверская, 1", updated.Address)
+	require.Equal(t, "09:00", updated.WorkingHours["mon"].Open)
+
+	res = patchJSON(t, ts.URL+"/pvz/"+pvzID, modToken, map[string]any{
+		"workingHours": map[string]any{"mon": map[string]string{"open": "21:00", "close": "09:00"}},
+	})
+	requireStatus(t, res, http.StatusBadRequest, "PATCH /pvz/{id} with inverted hours")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/decommission", modToken, nil)
+	requireStatus(t, res, http.StatusConflict, "decommission with open reception")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/close_last_reception", clientToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/decommission", modToken, nil)
+	requireStatus(t, res, http.StatusOK, "decommission")
+	var decommissioned struct {
+		DecommissionedAt *time.Time `json:"decommissionedAt"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&decommissioned))
+	_ = res.Body.Close()
+	require.NotNil(t, decommissioned.DecommissionedAt)
+
+	res = postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusBadRequest, "POST /receptions on decommissioned PVZ")
+	_ = res.Body.Close()
+	res = postJSON(t, ts.URL+"/receptions/"+opened.ID+"/reopen", modToken, nil)
+	requireStatus(t, res, http.StatusConflict, "POST /receptions/{id}/reopen on decommissioned PVZ")
+	_ = res.Body.Close()
+
+	listed := func(query string) int {
+		t.Helper()
+		res := get(t, ts.URL+"/pvz?openedBy="+opened.OpenedBy+query, modToken)
+		requireStatus(t, res, http.StatusOK, "GET /pvz"+query)
+		defer res.Body.Close()
+		var items []json.RawMessage
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&items))
+		return len(items)
+	}
+	require.Equal(t, 0, listed(""))
+	require.Equal(t, 1, listed("&includeDecommissioned=true"))
+
+	res = get(t, ts.URL+"/pvz/"+pvzID, clientToken)
+	requireStatus(t, res, http.StatusOK, "GET /pvz/{id} after decommission")
+	_ = res.Body.Close()
+}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPVZListFilters(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	var employeeID string
+	fill := func(city string, types ...string) string {
+		t.Helper()
+		pvzID := createPVZ(t, ts.URL, modToken, city)
+		res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+		requireStatus(t, res, http.StatusCreated, "POST /receptions")
+		var rec struct {
+			OpenedBy string `json:"openedBy"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&rec))
+		_ = res.Body.Close()
+		employeeID = rec.OpenedBy
+		for _, typ := range types {
+			res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": typ})
+			requireStatus(t, res, http.StatusCreated, "POST /products")
+			_ = res.Body.Close()
+		}
+		return pvzID
+	}
+	kazanShoes := fill("Казань", "обувь", "обувь")
+	kazanClosed := fill("Казань", "одежда")
+	res := postJSON(t, ts.URL+"/pvz/"+kazanClosed+"/close_last_reception", clientToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	_ = res.Body.Close()
+	moscowShoes := fill("Москва", "обувь")
+
+	list := func(params url.Values, want int) []string {
+		t.Helper()
+		params.Set("openedBy", employeeID)
+		res := get(t, ts.URL+"/pvz?"+params.Encode(), modToken)
+		requireStatus(t, res, want, "GET /pvz?"+params.Encode())
+		defer res.Body.Close()
+		if want != http.StatusOK {
+			return nil
+		}
+		var items []struct {
+			PVZ struct {
+				ID string `json:"id"`
+			} `json:"pvz"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&items))
+		ids := make([]string, 0, len(items))
+		for _, it := range items {
+			ids = append(ids, it.PVZ.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	sorted := func(ids ...string) []string {
+		sort.Strings(ids)
+		return ids
+	}
+
+	require.Equal(t, sorted(kazanShoes), list(url.Values{
+		"city": {"Kazan"}, "hasOpenReception": {"true"}, "productType": {"обувь"},
+	}, http.StatusOK))
+	require.Equal(t, sorted(kazanShoes, kazanClosed), list(url.Values{"city": {"Казань"}}, http.StatusOK))
+	require.Equal(t, sorted(kazanClosed), list(url.Values{"hasOpenReception": {"false"}}, http.StatusOK))
+	require.Equal(t, sorted(kazanClosed), list(url.Values{"receptionStatus": {"close"}}, http.StatusOK))
+	require.Equal(t, sorted(kazanShoes), list(url.Values{"productType": {"обувь"}, "minProducts": {"2"}}, http.StatusOK))
+	require.Equal(t, sorted(kazanClosed, moscowShoes), list(url.Values{"maxProducts": {"1"}}, http.StatusOK))
+
+	list(url.Values{"minProducts": {"3"}, "maxProducts": {"1"}}, http.StatusBadRequest)
+	list(url.Values{"receptionStatus": {"lost"}}, http.StatusBadRequest)
+	list(url.Values{"productType": {"не товар"}}, http.StatusBadRequest)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"pvz-service/internal/adapter/auth/jwt"
 	"pvz-service/internal/adapter/auth/password"
@@ -16,7 +17,11 @@ import (
 	"pvz-service/internal/config"
 	"pvz-service/internal/transport/http/handler"
 	"pvz-service/internal/transport/http/middleware"
+	auditUC "pvz-service/internal/usecase/audit"
 	"pvz-service/internal/usecase/auth"
+	catalogUC "pvz-service/internal/usecase/catalog"
+	cityUC "pvz-service/internal/usecase/city"
+	idempotencyUC "pvz-service/internal/usecase/idempotency"
 	pvzUC "pvz-service/internal/usecase/pvz"
 	receptionUC "pvz-service/internal/usecase/reception"
 
@@ -97,16 +102,19 @@ func setupServer(t *testing.T) (*httptest.Server, func()) {
 	clock := clockad.RealClock{}
 
 	userRepo := db.UserRepo()
-	pvzRepo := db.PVZRepo()
-	receptionRepo := db.ReceptionRepo()
-	productRepo := db.ProductRepo()
+	pvzReadModel := db.PVZReadModel()
 
 	authService := auth.NewService(userRepo, tokenManager, passwordHasher, clock)
-	pvzService := pvzUC.NewService(pvzRepo, receptionRepo, productRepo, clock)
-	receptionService := receptionUC.NewService(pvzRepo, receptionRepo, productRepo, clock)
+	cityService := cityUC.NewService(db.CityRepo(), clock, cityUC.DefaultCacheTTL)
+	pvzService := pvzUC.NewService(db, pvzReadModel, cityService, clock)
+	catalogService := catalogUC.NewService(db.ProductTypeRepo(), clock, catalogUC.DefaultCacheTTL)
+	receptionService := receptionUC.NewService(db, catalogService, clock, receptionUC.DefaultReopenWindow)
 
 	authHandler := handler.NewAuthHandler(authService)
-	pvzHandler := handler.NewPVZHandler(pvzService, receptionService)
+	pvzHandler := handler.NewPVZHandler(pvzService, receptionService, catalogService)
+	auditHandler := handler.NewAuditHandler(auditUC.NewService(db.AuditRepo()))
+	productTypeHandler := handler.NewProductTypeHandler(catalogService)
+	cityHandler := handler.NewCityHandler(cityService)
 
 	r := chi.NewRouter()
 
@@ -118,15 +126,40 @@ func setupServer(t *testing.T) (*httptest.Server, func()) {
 	// protected (как в api.New)
 	r.Group(func(pr chi.Router) {
 		pr.Use(middleware.AuthMiddleware(tokenManager))
+		idempotent := middleware.IdempotencyMiddleware(idempotencyUC.NewService(db.IdempotencyRepo(), clock, idempotencyUC.DefaultTTL, idempotencyUC.DefaultLease))
 
-		pr.With(middleware.RequireRole("moderator")).Post("/pvz", pvzHandler.CreatePVZ)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/pvz", pvzHandler.CreatePVZ)
 		pr.With(middleware.RequireRole("employee", "moderator")).Get("/pvz", pvzHandler.ListPVZ)
-
-		pr.With(middleware.RequireRole("employee")).Post("/receptions", pvzHandler.CreateReception)
-		pr.With(middleware.RequireRole("employee")).Post("/products", pvzHandler.AddProduct)
-
-		pr.With(middleware.RequireRole("employee")).Post("/pvz/{pvzId}/close_last_reception", pvzHandler.CloseLastReception)
-		pr.With(middleware.RequireRole("employee")).Post("/pvz/{pvzId}/delete_last_product", pvzHandler.DeleteLastProduct)
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/pvz/nearest", pvzHandler.FindNearestPVZ)
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/pvz/{pvzId}", pvzHandler.GetPVZ)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Patch("/pvz/{pvzId}", pvzHandler.UpdatePVZ)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/pvz/{pvzId}/decommission", pvzHandler.DecommissionPVZ)
+
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/receptions", pvzHandler.CreateReception)
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/products", pvzHandler.AddProduct)
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/products/batch", pvzHandler.AddProductsBatch)
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/products/by-barcode/{code}", pvzHandler.FindProductByBarcode)
+
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/pvz/{pvzId}/close_last_reception", pvzHandler.CloseLastReception)
+		pr.With(middleware.RequireRole("employee"), idempotent).Post("/pvz/{pvzId}/delete_last_product", pvzHandler.DeleteLastProduct)
+		pr.With(middleware.RequireRole("employee"), idempotent).Delete("/pvz/{pvzId}/products/{productId}", pvzHandler.DeleteProduct)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/receptions/{receptionId}/reopen", pvzHandler.ReopenReception)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/receptions/{receptionId}/cancel", pvzHandler.CancelReception)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Delete("/receptions/{receptionId}", pvzHandler.DeleteReception)
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/pvz/{pvzId}/capacity", pvzHandler.GetCapacity)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Put("/pvz/{pvzId}/capacity", pvzHandler.SetCapacity)
+
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/product-types", productTypeHandler.List)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/product-types", productTypeHandler.Create)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Patch("/product-types/{code}", productTypeHandler.Update)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Delete("/product-types/{code}", productTypeHandler.Delete)
+
+		pr.With(middleware.RequireRole("employee", "moderator")).Get("/cities", cityHandler.List)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Post("/cities", cityHandler.Create)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Patch("/cities/{name}", cityHandler.Update)
+		pr.With(middleware.RequireRole("moderator"), idempotent).Delete("/cities/{name}", cityHandler.Delete)
+
+		pr.With(middleware.RequireRole("moderator")).Get("/audit", auditHandler.List)
 	})
 
 	ts := httptest.NewServer(r)
@@ -177,5 +210,14 @@ func TestPVZFlow(t *testing.T) {
 
 	res = postJSON(t, ts.URL+"/pvz/"+pvzResp.ID+"/close_last_reception", clientToken, nil)
 	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	var closed struct {
+		ClosedAt     *time.Time `json:"closedAt"`
+		ClosedBy     string     `json:"closedBy"`
+		ProductCount int        `json:"productCount"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&closed))
 	_ = res.Body.Close()
+	require.NotNil(t, closed.ClosedAt)
+	require.NotEmpty(t, closed.ClosedBy)
+	require.Equal(t, 50, closed.ProductCount)
 }
@@ -0,0 +1,126 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"pvz-service/internal/adapter/db/repo"
+	clockad "pvz-service/internal/adapter/time"
+	"pvz-service/internal/config"
+	"pvz-service/internal/domain/product"
+	"pvz-service/internal/domain/pvz"
+	"pvz-service/internal/domain/reception"
+	pvzUC "pvz-service/internal/usecase/pvz"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+type countingConn struct {
+	pool    *pgxpool.Pool
+	queries atomic.Int64
+}
+
+func (c *countingConn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	c.queries.Add(1)
+	return c.pool.Query(ctx, sql, args...)
+}
+
+func openPool(tb testing.TB) *pgxpool.Pool {
+	tb.Helper()
+
+	cfg := config.Load()
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		cfg.DB.User, cfg.DB.Password, cfg.DB.Host, 15433, cfg.DB.Name)
+	pool, err := pgxpool.New(context.Background(), dsn)
+	require.NoError(tb, err)
+	require.NoError(tb, pool.Ping(context.Background()))
+	return pool
+}
+
+// seedPVZs создаёт pvzCount ПВЗ, в каждом receptionsPerPVZ приёмок по productsPerReception товаров;
+// последняя приёмка остаётся открытой
+func seedPVZs(tb testing.TB, pool *pgxpool.Pool, pvzCount, receptionsPerPVZ, productsPerReception int) {
+	tb.Helper()
+
+	ctx := context.Background()
+	pvzRepo := repo.NewPVZRepo(pool)
+	receptionRepo := repo.NewReceptionRepo(pool)
+	productRepo := repo.NewProductRepo(pool)
+	now := time.Now().UTC()
+
+	for i := 0; i < pvzCount; i++ {
+		p := &pvz.PVZ{ID: uuid.New().String(), City: "Казань", Timezone: "Europe/Moscow", CreatedAt: now}
+		require.NoError(tb, pvzRepo.Create(ctx, p))
+		for j := 0; j < receptionsPerPVZ; j++ {
+			rec := &reception.Reception{
+				ID:        uuid.New().String(),
+				PVZID:     p.ID,
+				StartedAt: now.Add(time.Duration(j) * time.Second),
+				Status:    reception.StatusClosed,
+			}
+			if j == receptionsPerPVZ-1 {
+				rec.Status = reception.StatusInProgress
+			}
+			require.NoError(tb, receptionRepo.Create(ctx, rec))
+			for k := 0; k < productsPerReception; k++ {
+				require.NoError(tb, productRepo.Create(ctx, &product.Product{
+					ID:          uuid.New().String(),
+					ReceptionID: rec.ID,
+					AddedAt:     rec.StartedAt.Add(time.Duration(k) * time.Millisecond),
+					Type:        "electronics",
+				}))
+			}
+		}
+	}
+}
+
+func TestPVZListQueryCountIsConstant(t *testing.T) {
+	pool := openPool(t)
+	defer pool.Close()
+
+	conn := &countingConn{pool: pool}
+	svc := pvzUC.NewService(nil, repo.NewPVZReadModel(conn), nil, clockad.RealClock{})
+
+	for _, size := range []int{1, 5, 10} {
+		seededFrom := time.Now().UTC()
+		seedPVZs(t, pool, size, size, size)
+
+		conn.queries.Store(0)
+		list, err := svc.List(context.Background(), pvzUC.ListParams{DateField: "pvz", From: &seededFrom, Limit: 30})
+		require.NoError(t, err)
+		require.Len(t, list.Items, size)
+		require.EqualValues(t, 2, conn.queries.Load(), "size=%d", size)
+
+		for _, item := range list.Items {
+			require.Len(t, item.Receptions, size)
+			open := item.Receptions[size-1]
+			require.Equal(t, reception.StatusInProgress, open.Status)
+			require.Equal(t, size, open.ProductCount, "an open reception reports its live count")
+		}
+	}
+}
+
+func BenchmarkPVZList(b *testing.B) {
+	pool := openPool(b)
+	defer pool.Close()
+
+	seedPVZs(b, pool, 30, 20, 10)
+
+	conn := &countingConn{pool: pool}
+	svc := pvzUC.NewService(nil, repo.NewPVZReadModel(conn), nil, clockad.RealClock{})
+	from := time.Now().UTC().Add(-time.Minute)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := svc.List(context.Background(), pvzUC.ListParams{From: &from, Limit: 30}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(conn.queries.Load())/float64(b.N), "queries/op")
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNearestPVZ(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+
+	// a random spot in the southern Pacific keeps runs from seeing each other
+	lat := -50 + rand.Float64()*10
+	lon := -150 + rand.Float64()*20
+
+	create := func(name string, dLat float64) string {
+		t.Helper()
+		res := postJSON(t, ts.URL+"/pvz", modToken, map[string]any{
+			"city":      "Москва",
+			"name":      name,
+			"address":   "ул. Тестовая, 1",
+			"latitude":  lat + dLat,
+			"longitude": lon,
+			"workingHours": map[string]any{
+				"mon": map[string]string{"open": "09:00", "close": "21:00"},
+			},
+		})
+		requireStatus(t, res, http.StatusCreated, "POST /pvz "+name)
+		var created struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&created))
+		_ = res.Body.Close()
+		return created.ID
+	}
+	far := create("дальний", 0.02)
+	near := create("ближний", 0)
+	middle := create("средний", 0.01)
+
+	res := postJSON(t, ts.URL+"/pvz", modToken, map[string]any{"city": "Москва", "latitude": lat})
+	requireStatus(t, res, http.StatusBadRequest, "POST /pvz with latitude only")
+	_ = res.Body.Close()
+	res = postJSON(t, ts.URL+"/pvz", modToken, map[string]any{"city": "Москва", "latitude": 91, "longitude": lon})
+	requireStatus(t, res, http.StatusBadRequest, "POST /pvz with latitude out of range")
+	_ = res.Body.Close()
+	res = postJSON(t, ts.URL+"/pvz", modToken, map[string]any{
+		"city":         "Москва",
+		"workingHours": map[string]any{"holiday": map[string]string{"open": "09:00", "close": "21:00"}},
+	})
+	requireStatus(t, res, http.StatusBadRequest, "POST /pvz with unknown weekday")
+	_ = res.Body.Close()
+
+	res = get(t, ts.URL+fmt.Sprintf("/pvz/nearest?lat=%f&lon=%f&radiusKm=5", lat, lon), modToken)
+	requireStatus(t, res, http.StatusOK, "GET /pvz/nearest")
+	var found []struct {
+		PVZ struct {
+			ID string `json:"id"`
+		} `json:"pvz"`
+		DistanceKm float64 `json:"distanceKm"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&found))
+	_ = res.Body.Close()
+	require.Len(t, found, 3)
+	require.Equal(t, []string{near, middle, far}, []string{found[0].PVZ.ID, found[1].PVZ.ID, found[2].PVZ.ID})
+	require.InDelta(t, 0, found[0].DistanceKm, 0.01)
+	require.InDelta(t, 1.112, found[1].DistanceKm, 0.01)
+
+	res = get(t, ts.URL+fmt.Sprintf("/pvz/nearest?lat=%f&lon=%f&radiusKm=1.5", lat, lon), modToken)
+	requireStatus(t, res, http.StatusOK, "GET /pvz/nearest within 1.5 km")
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&found))
+	_ = res.Body.Close()
+	require.Len(t, found, 2)
+
+	res = get(t, ts.URL+"/pvz/nearest?lat=100&lon=0", modToken)
+	requireStatus(t, res, http.StatusBadRequest, "GET /pvz/nearest with invalid latitude")
+	_ = res.Body.Close()
+}
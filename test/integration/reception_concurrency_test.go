@@ -0,0 +1,200 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"pvz-service/internal/adapter/db/postgres"
+	"pvz-service/internal/config"
+	"pvz-service/internal/domain/reception"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func createPVZ(t *testing.T, baseURL, modToken, city string) string {
+	t.Helper()
+
+	res := postJSON(t, baseURL+"/pvz", modToken, map[string]any{"city": city})
+	requireStatus(t, res, http.StatusCreated, "POST /pvz")
+	var pvzResp struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&pvzResp))
+	_ = res.Body.Close()
+	require.NotEmpty(t, pvzResp.ID)
+	return pvzResp.ID
+}
+
+func dummyToken(t *testing.T, baseURL, role string) string {
+	t.Helper()
+
+	res := postJSON(t, baseURL+"/dummyLogin", "", map[string]any{"role": role})
+	requireStatus(t, res, http.StatusOK, "POST /dummyLogin ("+role+")")
+	return mustReadTokenString(t, res)
+}
+
+// postStatus is postJSON for worker goroutines: it reports failures instead of
+// stopping the test, which only the test goroutine may do.
+func postStatus(url, token string, payload any) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = res.Body.Close()
+	return res.StatusCode, nil
+}
+
+func TestConcurrentOpenReception(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	pvzID := createPVZ(t, ts.URL, dummyToken(t, ts.URL, "moderator"), "Казань")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	const workers = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		created int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code, err := postStatus(ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if code == http.StatusCreated {
+				mu.Lock()
+				created++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Ровно одна приёмка должна открыться, остальные получают ErrReceptionAlreadyOpen
+	require.Equal(t, 1, created)
+}
+
+func TestConcurrentAddAndClose(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	pvzID := createPVZ(t, ts.URL, dummyToken(t, ts.URL, "moderator"), "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	const workers = 30
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		added int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			code, err := postStatus(ts.URL+"/products", clientToken, map[string]any{
+				"pvzId": pvzID,
+				"type":  "обувь",
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if code == http.StatusCreated {
+				mu.Lock()
+				added++
+				mu.Unlock()
+			}
+		}()
+	}
+	closeStatus := make(chan int, 1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		code, err := postStatus(ts.URL+"/pvz/"+pvzID+"/close_last_reception", clientToken, nil)
+		if err != nil {
+			t.Error(err)
+		}
+		closeStatus <- code
+	}()
+	wg.Wait()
+	require.Equal(t, http.StatusOK, <-closeStatus, "POST /pvz/{id}/close_last_reception")
+
+	// Каждый успешно добавленный товар должен оказаться в закрытой приёмке
+	res = get(t, ts.URL+"/pvz/"+pvzID, clientToken)
+	requireStatus(t, res, http.StatusOK, "GET /pvz/{id}")
+	var details struct {
+		Receptions []struct {
+			Status       string `json:"status"`
+			ProductCount int    `json:"productCount"`
+		} `json:"receptions"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&details))
+	_ = res.Body.Close()
+
+	require.Len(t, details.Receptions, 1)
+	require.Equal(t, "close", details.Receptions[0].Status)
+	require.Equal(t, added, details.Receptions[0].ProductCount)
+}
+
+func TestSecondInProgressReceptionRejectedByDatabase(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	cfg := config.Load()
+	db, err := postgres.NewDB(cfg.DB.Host, 15433, cfg.DB.User, cfg.DB.Password, cfg.DB.Name)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	empToken := dummyToken(t, ts.URL, "employee")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+
+	ctx := context.Background()
+	inProgress := func() *reception.Reception {
+		return &reception.Reception{
+			ID:        uuid.NewString(),
+			PVZID:     pvzID,
+			StartedAt: time.Now().UTC(),
+			Status:    reception.StatusInProgress,
+		}
+	}
+
+	// straight to the repository, past the use-case check for an open reception
+	require.NoError(t, db.ReceptionRepo().Create(ctx, inProgress()))
+	err = db.ReceptionRepo().Create(ctx, inProgress())
+	require.ErrorIs(t, err, reception.ErrReceptionAlreadyOpen)
+
+	res := postJSON(t, ts.URL+"/receptions", empToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusBadRequest, "POST /receptions with one already open")
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+	require.Contains(t, string(body), reception.ErrReceptionAlreadyOpen.Error())
+}
@@ -0,0 +1,170 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type auditEntry struct {
+	OccurredAt  time.Time         `json:"occurredAt"`
+	ActorID     string            `json:"actorId"`
+	ActorRole   string            `json:"actorRole"`
+	Action      string            `json:"action"`
+	PVZID       string            `json:"pvzId"`
+	ReceptionID string            `json:"receptionId"`
+	ProductID   string            `json:"productId"`
+	Details     map[string]string `json:"details"`
+}
+
+func listAudit(t *testing.T, baseURL, token string, params url.Values) []auditEntry {
+	t.Helper()
+	res := get(t, baseURL+"/audit?"+params.Encode(), token)
+	requireStatus(t, res, http.StatusOK, "GET /audit?"+params.Encode())
+	defer res.Body.Close()
+	var entries []auditEntry
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&entries))
+	return entries
+}
+
+func TestDeleteCancelledReception(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	receptionID, _ := decodeReception(t, res)
+	res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": "обувь"})
+	requireStatus(t, res, http.StatusCreated, "POST /products")
+	_ = res.Body.Close()
+
+	res = deleteReq(t, ts.URL+"/receptions/"+receptionID, clientToken)
+	requireStatus(t, res, http.StatusForbidden, "DELETE /receptions/{id} as employee")
+	_ = res.Body.Close()
+	res = deleteReq(t, ts.URL+"/receptions/"+receptionID, modToken)
+	requireStatus(t, res, http.StatusConflict, "DELETE open reception")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/receptions/"+receptionID+"/cancel", modToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /receptions/{id}/cancel")
+	_ = res.Body.Close()
+	res = deleteReq(t, ts.URL+"/receptions/"+receptionID, modToken)
+	requireStatus(t, res, http.StatusNoContent, "DELETE cancelled reception")
+	_ = res.Body.Close()
+	res = deleteReq(t, ts.URL+"/receptions/"+receptionID, modToken)
+	requireStatus(t, res, http.StatusNotFound, "DELETE deleted reception")
+	_ = res.Body.Close()
+	res = postJSON(t, ts.URL+"/receptions/"+receptionID+"/reopen", modToken, nil)
+	requireStatus(t, res, http.StatusNotFound, "POST /receptions/{id}/reopen of deleted reception")
+	_ = res.Body.Close()
+
+	res = get(t, ts.URL+"/pvz/"+pvzID, clientToken)
+	requireStatus(t, res, http.StatusOK, "GET /pvz/{id}")
+	var details struct {
+		Receptions     []struct{} `json:"receptions"`
+		ReceptionCount int        `json:"receptionCount"`
+		ProductCount   int        `json:"productCount"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&details))
+	_ = res.Body.Close()
+	require.Empty(t, details.Receptions)
+	require.Zero(t, details.ReceptionCount)
+	require.Zero(t, details.ProductCount)
+
+	entries := listAudit(t, ts.URL, modToken, url.Values{"pvzId": {pvzID}})
+	var deleted *auditEntry
+	for i := range entries {
+		if entries[i].Action == "reception_deleted" {
+			deleted = &entries[i]
+		}
+	}
+	require.NotNil(t, deleted)
+	require.Equal(t, receptionID, deleted.ReceptionID)
+	require.Equal(t, "moderator", deleted.ActorRole)
+	require.Equal(t, "cancelled", deleted.Details["receptionStatus"])
+
+	// a new reception can be opened after the deleted one
+	res = postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions after delete")
+	_ = res.Body.Close()
+}
+
+func TestAuditQueryFilters(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Казань")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := get(t, ts.URL+"/audit?pvzId="+pvzID, clientToken)
+	requireStatus(t, res, http.StatusForbidden, "GET /audit as employee")
+	_ = res.Body.Close()
+	for _, bad := range []string{"pvzId=not-a-uuid", "startDate=yesterday", "endDate=2024-13-01", "limit=0", "limit=abc"} {
+		res = get(t, ts.URL+"/audit?"+bad, modToken)
+		requireStatus(t, res, http.StatusBadRequest, "GET /audit?"+bad)
+		_ = res.Body.Close()
+	}
+
+	res = postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+	for i := 0; i < 2; i++ {
+		res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": "одежда"})
+		requireStatus(t, res, http.StatusCreated, "POST /products")
+		_ = res.Body.Close()
+	}
+
+	all := listAudit(t, ts.URL, modToken, url.Values{"pvzId": {pvzID}})
+	require.Len(t, all, 4, "pvz_created, reception_opened and two product_added")
+	actions := map[string]int{}
+	var employeeID, moderatorID string
+	for _, e := range all {
+		require.Equal(t, pvzID, e.PVZID)
+		actions[e.Action]++
+		switch e.ActorRole {
+		case "employee":
+			employeeID = e.ActorID
+		case "moderator":
+			moderatorID = e.ActorID
+		}
+	}
+	require.Equal(t, map[string]int{"pvz_created": 1, "reception_opened": 1, "product_added": 2}, actions)
+	require.NotEmpty(t, employeeID)
+	require.NotEmpty(t, moderatorID)
+
+	byModerator := listAudit(t, ts.URL, modToken, url.Values{"pvzId": {pvzID}, "actorId": {moderatorID}})
+	require.Len(t, byModerator, 1)
+	require.Equal(t, "pvz_created", byModerator[0].Action)
+	require.Len(t, listAudit(t, ts.URL, modToken, url.Values{"pvzId": {pvzID}, "actorId": {employeeID}}), 3)
+
+	limited := listAudit(t, ts.URL, modToken, url.Values{"pvzId": {pvzID}, "limit": {"2"}})
+	require.Len(t, limited, 2)
+
+	// the range is inclusive on both ends and keeps microsecond precision
+	var opened auditEntry
+	for _, e := range all {
+		if e.Action == "reception_opened" {
+			opened = e
+		}
+	}
+	inRange := listAudit(t, ts.URL, modToken, url.Values{
+		"pvzId":     {pvzID},
+		"startDate": {stamp(opened.OccurredAt)},
+		"endDate":   {stamp(opened.OccurredAt)},
+	})
+	require.Len(t, inRange, 1)
+	require.Equal(t, "reception_opened", inRange[0].Action)
+	require.Empty(t, listAudit(t, ts.URL, modToken, url.Values{
+		"pvzId":     {pvzID},
+		"startDate": {stamp(time.Now().Add(time.Hour))},
+	}))
+}
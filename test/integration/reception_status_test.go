@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeReception(t *testing.T, res *http.Response) (id, status string) {
+	t.Helper()
+	defer res.Body.Close()
+
+	var rec struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&rec))
+	return rec.ID, rec.Status
+}
+
+func TestReceptionReopenAndCancel(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Москва")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	receptionID, _ := decodeReception(t, res)
+
+	res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": "обувь"})
+	requireStatus(t, res, http.StatusCreated, "POST /products")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/pvz/"+pvzID+"/close_last_reception", clientToken, nil)
+	requireStatus(t, res, http.StatusOK, "POST /pvz/{id}/close_last_reception")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/receptions/"+receptionID+"/reopen", clientToken, nil)
+	requireStatus(t, res, http.StatusForbidden, "reopen as employee")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/receptions/"+receptionID+"/cancel", modToken, nil)
+	requireStatus(t, res, http.StatusConflict, "cancel closed reception with products")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/receptions/"+receptionID+"/reopen", modToken, nil)
+	requireStatus(t, res, http.StatusOK, "reopen closed reception")
+	_, status := decodeReception(t, res)
+	require.Equal(t, "reopened", status)
+
+	res = postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusBadRequest, "open while reopened")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": "обувь"})
+	requireStatus(t, res, http.StatusCreated, "POST /products to reopened reception")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/receptions/"+receptionID+"/cancel", modToken, nil)
+	requireStatus(t, res, http.StatusOK, "cancel reopened reception")
+	_, status = decodeReception(t, res)
+	require.Equal(t, "cancelled", status)
+
+	res = postJSON(t, ts.URL+"/receptions/"+receptionID+"/reopen", modToken, nil)
+	requireStatus(t, res, http.StatusConflict, "reopen cancelled reception")
+	_ = res.Body.Close()
+
+	res = postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "open after cancel")
+	_ = res.Body.Close()
+}
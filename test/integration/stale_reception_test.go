@@ -0,0 +1,205 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"pvz-service/internal/adapter/db/postgres"
+	"pvz-service/internal/adapter/observability/metrics"
+	clockad "pvz-service/internal/adapter/time"
+	"pvz-service/internal/config"
+	"pvz-service/internal/domain/audit"
+	"pvz-service/internal/domain/reception"
+	catalogUC "pvz-service/internal/usecase/catalog"
+	cityUC "pvz-service/internal/usecase/city"
+	"pvz-service/internal/usecase/ports"
+	pvzUC "pvz-service/internal/usecase/pvz"
+	receptionUC "pvz-service/internal/usecase/reception"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fixedClock struct{ now time.Time }
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func TestStaleReceptionsAutoClose(t *testing.T) {
+	cfg := config.Load()
+	db, err := postgres.NewDB(cfg.DB.Host, 15433, cfg.DB.User, cfg.DB.Password, cfg.DB.Name)
+	require.NoError(t, err)
+	require.NoError(t, db.Ping(context.Background()))
+	t.Cleanup(db.Close)
+
+	ctx := context.Background()
+	newReceptions := func(clock ports.Clock) *receptionUC.Service {
+		catalog := catalogUC.NewService(db.ProductTypeRepo(), clock, catalogUC.DefaultCacheTTL)
+		return receptionUC.NewService(db, catalog, clock, receptionUC.DefaultReopenWindow)
+	}
+	realClock := clockad.RealClock{}
+	pvzs := pvzUC.NewService(db, db.PVZReadModel(), cityUC.NewService(db.CityRepo(), realClock, cityUC.DefaultCacheTTL), realClock)
+	receptions := newReceptions(realClock)
+
+	short, err := pvzs.Create(ctx, pvzUC.CreateParams{City: "Москва", StaleReceptionAfter: time.Hour})
+	require.NoError(t, err)
+	regular, err := pvzs.Create(ctx, pvzUC.CreateParams{City: "Москва"})
+	require.NoError(t, err)
+
+	_, err = receptions.Open(ctx, short.ID)
+	require.NoError(t, err)
+	_, err = receptions.AddProduct(ctx, short.ID, "одежда")
+	require.NoError(t, err)
+	_, err = receptions.Open(ctx, regular.ID)
+	require.NoError(t, err)
+
+	// два часа спустя: порог ПВЗ short (1ч) пройден, общий (24ч) — нет
+	later := fixedClock{now: time.Now().Add(2 * time.Hour)}
+	sweeper := receptionUC.NewSweeper(newReceptions(later), metrics.NewPromMetrics(), zap.NewNop(), receptionUC.SweeperConfig{
+		Interval:  time.Minute,
+		MaxAge:    24 * time.Hour,
+		Action:    receptionUC.StaleActionClose,
+		BatchSize: 100,
+	})
+	for {
+		n, err := sweeper.Sweep(ctx)
+		require.NoError(t, err)
+		if n < 100 {
+			break
+		}
+	}
+
+	open, err := db.ReceptionRepo().GetOpenByPVZ(ctx, short.ID)
+	require.NoError(t, err)
+	require.Nil(t, open)
+
+	open, err = db.ReceptionRepo().GetOpenByPVZ(ctx, regular.ID)
+	require.NoError(t, err)
+	require.NotNil(t, open)
+
+	entries, err := db.AuditRepo().List(ctx, ports.AuditFilter{PVZID: short.ID, Limit: 10})
+	require.NoError(t, err)
+	var closedBySystem bool
+	for _, e := range entries {
+		if e.Action == audit.ActionReceptionClosed && e.ActorID == audit.SystemActorID {
+			closedBySystem = true
+		}
+	}
+	require.True(t, closedBySystem)
+
+	_, err = receptions.Open(ctx, short.ID)
+	require.NoError(t, err)
+}
+
+func TestStaleReceptionFlaggedOnceByConcurrentSweepers(t *testing.T) {
+	cfg := config.Load()
+	db, err := postgres.NewDB(cfg.DB.Host, 15433, cfg.DB.User, cfg.DB.Password, cfg.DB.Name)
+	require.NoError(t, err)
+	require.NoError(t, db.Ping(context.Background()))
+	t.Cleanup(db.Close)
+
+	ctx := context.Background()
+	realClock := clockad.RealClock{}
+	pvzs := pvzUC.NewService(db, db.PVZReadModel(), cityUC.NewService(db.CityRepo(), realClock, cityUC.DefaultCacheTTL), realClock)
+	catalog := catalogUC.NewService(db.ProductTypeRepo(), realClock, catalogUC.DefaultCacheTTL)
+	receptions := receptionUC.NewService(db, catalog, realClock, receptionUC.DefaultReopenWindow)
+
+	p, err := pvzs.Create(ctx, pvzUC.CreateParams{City: "Казань", StaleReceptionAfter: time.Hour})
+	require.NoError(t, err)
+	rec, err := receptions.Open(ctx, p.ID)
+	require.NoError(t, err)
+
+	later := fixedClock{now: time.Now().Add(2 * time.Hour)}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		sweeper := receptionUC.NewSweeper(
+			receptionUC.NewService(db, catalog, later, receptionUC.DefaultReopenWindow),
+			metrics.NewPromMetrics(), zap.NewNop(), receptionUC.SweeperConfig{
+				Interval:  time.Minute,
+				MaxAge:    24 * time.Hour,
+				Action:    receptionUC.StaleActionFlag,
+				BatchSize: 100,
+			})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := sweeper.Sweep(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	n, err := db.ReceptionRepo().MarkStale(ctx, rec.ID, time.Now())
+	require.NoError(t, err)
+	require.Zero(t, n, "already flagged")
+
+	entries, err := db.AuditRepo().List(ctx, ports.AuditFilter{PVZID: p.ID, Limit: 10})
+	require.NoError(t, err)
+	flagged := 0
+	for _, e := range entries {
+		if e.Action == audit.ActionReceptionFlaggedStale {
+			flagged++
+		}
+	}
+	require.Equal(t, 1, flagged)
+}
+
+func TestStaleReopenedReceptionAgedFromReopening(t *testing.T) {
+	cfg := config.Load()
+	db, err := postgres.NewDB(cfg.DB.Host, 15433, cfg.DB.User, cfg.DB.Password, cfg.DB.Name)
+	require.NoError(t, err)
+	require.NoError(t, db.Ping(context.Background()))
+	t.Cleanup(db.Close)
+
+	ctx := context.Background()
+	realClock := clockad.RealClock{}
+	catalog := catalogUC.NewService(db.ProductTypeRepo(), realClock, catalogUC.DefaultCacheTTL)
+	at := func(now time.Time) *receptionUC.Service {
+		return receptionUC.NewService(db, catalog, fixedClock{now: now}, receptionUC.DefaultReopenWindow)
+	}
+	sweepAt := func(now time.Time) {
+		t.Helper()
+		sweeper := receptionUC.NewSweeper(at(now), metrics.NewPromMetrics(), zap.NewNop(), receptionUC.SweeperConfig{
+			Interval:  time.Minute,
+			MaxAge:    24 * time.Hour,
+			Action:    receptionUC.StaleActionClose,
+			BatchSize: 100,
+		})
+		for {
+			n, err := sweeper.Sweep(ctx)
+			require.NoError(t, err)
+			if n < 100 {
+				break
+			}
+		}
+	}
+	pvzs := pvzUC.NewService(db, db.PVZReadModel(), cityUC.NewService(db.CityRepo(), realClock, cityUC.DefaultCacheTTL), realClock)
+
+	p, err := pvzs.Create(ctx, pvzUC.CreateParams{City: "Москва", StaleReceptionAfter: time.Hour})
+	require.NoError(t, err)
+
+	// открыта и закрыта три часа назад, переоткрыта сейчас
+	now := time.Now()
+	rec, err := at(now.Add(-3*time.Hour)).Open(ctx, p.ID)
+	require.NoError(t, err)
+	_, err = at(now.Add(-3*time.Hour)).Close(ctx, p.ID)
+	require.NoError(t, err)
+	_, err = at(now).Reopen(ctx, rec.ID)
+	require.NoError(t, err)
+
+	sweepAt(now.Add(30 * time.Minute))
+	open, err := db.ReceptionRepo().GetOpenByPVZ(ctx, p.ID)
+	require.NoError(t, err)
+	require.NotNil(t, open, "the hour counts from the reopening")
+	require.Equal(t, reception.StatusReopened, open.Status)
+
+	sweepAt(now.Add(2 * time.Hour))
+	open, err = db.ReceptionRepo().GetOpenByPVZ(ctx, p.ID)
+	require.NoError(t, err)
+	require.Nil(t, open, "a stale reopened reception is closed too")
+
+	_, err = at(now.Add(2*time.Hour)).Open(ctx, p.ID)
+	require.NoError(t, err)
+}
@@ -0,0 +1,104 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type listedPVZ struct {
+	PVZ struct {
+		ID               string    `json:"id"`
+		RegistrationDate time.Time `json:"registrationDate"`
+		Timezone         string    `json:"timezone"`
+	} `json:"pvz"`
+	Receptions []struct {
+		Reception struct {
+			ID           string    `json:"id"`
+			DateTime     time.Time `json:"dateTime"`
+			ProductCount int       `json:"productCount"`
+		} `json:"reception"`
+		Products []struct {
+			ID string `json:"id"`
+		} `json:"products"`
+	} `json:"receptions"`
+}
+
+// findListedPVZ walks the listing with keyset pagination until it meets pvzID.
+func findListedPVZ(t *testing.T, baseURL, token string, params url.Values, pvzID string) *listedPVZ {
+	t.Helper()
+
+	params.Set("limit", "30")
+	for {
+		res := get(t, baseURL+"/pvz?"+params.Encode(), token)
+		requireStatus(t, res, http.StatusOK, "GET /pvz")
+		var items []listedPVZ
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&items))
+		_ = res.Body.Close()
+		for i := range items {
+			if items[i].PVZ.ID == pvzID {
+				return &items[i]
+			}
+		}
+		next := res.Header.Get("X-Next-Cursor")
+		if next == "" {
+			return nil
+		}
+		params.Set("cursor", next)
+	}
+}
+
+func TestListPVZInLocalTime(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	res := postJSON(t, ts.URL+"/pvz", modToken, map[string]any{"city": "Kazan", "timezone": "Asia/Vladivostok"})
+	requireStatus(t, res, http.StatusCreated, "POST /pvz")
+	var created struct {
+		ID       string `json:"id"`
+		Timezone string `json:"timezone"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&created))
+	_ = res.Body.Close()
+	require.Equal(t, "Asia/Vladivostok", created.Timezone)
+
+	res = postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": created.ID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	vladivostok, err := time.LoadLocation("Asia/Vladivostok")
+	require.NoError(t, err)
+	now := time.Now().In(vladivostok)
+	const wall = "2006-01-02T15:04:05"
+	window := url.Values{
+		"startDate": {now.Add(-time.Minute).Format(wall)},
+		"endDate":   {now.Add(time.Minute).Format(wall)},
+	}
+
+	utcParams := url.Values{"tz": {"utc"}}
+	for k, v := range window {
+		utcParams[k] = v
+	}
+	require.Nil(t, findListedPVZ(t, ts.URL, modToken, utcParams, created.ID),
+		"wall-clock bounds in utc mode must not match a reception 10 hours earlier")
+
+	localParams := url.Values{"tz": {"local"}}
+	for k, v := range window {
+		localParams[k] = v
+	}
+	item := findListedPVZ(t, ts.URL, modToken, localParams, created.ID)
+	require.NotNil(t, item)
+	require.Len(t, item.Receptions, 1)
+
+	_, offset := item.PVZ.RegistrationDate.Zone()
+	require.Equal(t, 10*60*60, offset)
+	_, offset = item.Receptions[0].Reception.DateTime.Zone()
+	require.Equal(t, 10*60*60, offset)
+}
@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	pvzdomain "pvz-service/internal/domain/pvz"
+	"pvz-service/internal/transport/grpc/pb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestWatchFollowsChangesMadeOverHTTP(t *testing.T) {
+	ts, cleanup := setupServer(t)
+	defer cleanup()
+	env := setupGRPC(t)
+
+	modToken := dummyToken(t, ts.URL, "moderator")
+	pvzID := createPVZ(t, ts.URL, modToken, "Казань")
+	clientToken := dummyToken(t, ts.URL, "employee")
+
+	start, err := env.db.OutboxRepo().LastSequence(context.Background())
+	require.NoError(t, err)
+
+	res := postJSON(t, ts.URL+"/receptions", clientToken, map[string]any{"pvzId": pvzID})
+	requireStatus(t, res, http.StatusCreated, "POST /receptions")
+	_ = res.Body.Close()
+
+	ctx, cancel := context.WithTimeout(withToken(context.Background(), clientToken), 10*time.Second)
+	defer cancel()
+	watch := func(env *grpcEnv, after uint64) pb.PVZService_WatchPVZClient {
+		stream, err := env.pvz.WatchPVZ(ctx, &pb.WatchPVZRequest{
+			Target:        &pb.WatchPVZRequest_PvzId{PvzId: pvzID},
+			AfterSequence: after,
+		})
+		require.NoError(t, err)
+		return stream
+	}
+
+	stream := watch(env, start)
+	opened, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pb.PVZEventType_PVZ_EVENT_TYPE_RECEPTION_OPENED, opened.GetType())
+	require.Greater(t, opened.GetSequence(), start)
+
+	// the stream is live now: later HTTP changes arrive as they commit
+	res = postJSON(t, ts.URL+"/products", clientToken, map[string]any{"pvzId": pvzID, "type": "обувь"})
+	requireStatus(t, res, http.StatusCreated, "POST /products")
+	_ = res.Body.Close()
+	added, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pb.PVZEventType_PVZ_EVENT_TYPE_PRODUCT_ADDED, added.GetType())
+	require.Greater(t, added.GetSequence(), opened.GetSequence())
+
+	// a restarted server resumes from the same sequence
+	restarted := watch(setupGRPC(t), opened.GetSequence())
+	resumed, err := restarted.Recv()
+	require.NoError(t, err)
+	require.Equal(t, added.GetSequence(), resumed.GetSequence())
+	require.Equal(t, added.GetProduct().GetId(), resumed.GetProduct().GetId())
+}
+
+func TestWatchCityFilterResolvesAliases(t *testing.T) {
+	env := setupGRPC(t)
+	suffix := uuid.New().String()[:8]
+	twins := []string{"Близнец-1 " + suffix, "Близнец-2 " + suffix}
+	for _, name := range twins {
+		require.NoError(t, env.db.CityRepo().Create(context.Background(), &pvzdomain.City{
+			Name:      name,
+			Aliases:   map[string]string{"en": "Twin " + suffix},
+			Timezone:  "Europe/Moscow",
+			Active:    true,
+			CreatedAt: time.Now(),
+		}))
+		t.Cleanup(func() { _ = env.db.CityRepo().Delete(context.Background(), name) })
+	}
+	modCtx := env.login(t, "moderator")
+	clientCtx := env.login(t, "employee")
+
+	created, err := env.pvz.CreatePVZ(modCtx, &pb.CreatePVZRequest{City: "Kazan"})
+	require.NoError(t, err)
+	start, err := env.db.OutboxRepo().LastSequence(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(clientCtx, 10*time.Second)
+	defer cancel()
+	stream, err := env.pvz.WatchPVZ(ctx, &pb.WatchPVZRequest{
+		Target:        &pb.WatchPVZRequest_City{City: " kazan "},
+		AfterSequence: start,
+	})
+	require.NoError(t, err)
+
+	_, err = env.pvz.OpenReception(clientCtx, &pb.OpenReceptionRequest{PvzId: created.GetId()})
+	require.NoError(t, err)
+	for {
+		ev, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, "Казань", ev.GetCity())
+		if ev.GetPvzId() == created.GetId() {
+			require.Equal(t, pb.PVZEventType_PVZ_EVENT_TYPE_RECEPTION_OPENED, ev.GetType())
+			break
+		}
+	}
+
+	ambiguous, err := env.pvz.WatchPVZ(ctx, &pb.WatchPVZRequest{
+		Target: &pb.WatchPVZRequest_City{City: "Twin " + suffix},
+	})
+	require.NoError(t, err)
+	_, err = ambiguous.Recv()
+	requireCode(t, codes.InvalidArgument, err)
+}
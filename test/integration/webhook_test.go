@@ -0,0 +1,247 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"pvz-service/internal/adapter/db/postgres"
+	clockad "pvz-service/internal/adapter/time"
+	webhookad "pvz-service/internal/adapter/webhook"
+	"pvz-service/internal/config"
+	"pvz-service/internal/domain/webhook"
+	catalogUC "pvz-service/internal/usecase/catalog"
+	cityUC "pvz-service/internal/usecase/city"
+	outboxUC "pvz-service/internal/usecase/outbox"
+	pvzUC "pvz-service/internal/usecase/pvz"
+	receptionUC "pvz-service/internal/usecase/reception"
+	webhookUC "pvz-service/internal/usecase/webhook"
+
+	"github.com/stretchr/testify/require"
+)
+
+type receivedHook struct {
+	body      []byte
+	signature string
+}
+
+func newReceiver(t *testing.T, status int) (*httptest.Server, chan receivedHook) {
+	t.Helper()
+
+	received := make(chan receivedHook, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		select {
+		case received <- receivedHook{body: body, signature: r.Header.Get(webhookad.SignatureHeader)}:
+		default:
+		}
+		w.WriteHeader(status)
+	}))
+	return srv, received
+}
+
+type webhookEnv struct {
+	db         *postgres.PostgresDB
+	pvz        *pvzUC.Service
+	reception  *receptionUC.Service
+	webhooks   *webhookUC.Service
+	relay      *outboxUC.Relay
+	dispatcher *webhookUC.Dispatcher
+}
+
+func setupWebhookEnv(t *testing.T, maxConsecutiveFailures int) *webhookEnv {
+	t.Helper()
+
+	cfg := config.Load()
+	db, err := postgres.NewDB(cfg.DB.Host, 15433, cfg.DB.User, cfg.DB.Password, cfg.DB.Name)
+	require.NoError(t, err)
+	require.NoError(t, db.Ping(context.Background()))
+	t.Cleanup(db.Close)
+
+	clock := clockad.RealClock{}
+	cities := cityUC.NewService(db.CityRepo(), clock, cityUC.DefaultCacheTTL)
+	webhooks := webhookUC.NewService(db.WebhookRepo(), cities, clock)
+	return &webhookEnv{
+		db:        db,
+		pvz:       pvzUC.NewService(db, db.PVZReadModel(), cities, clock),
+		reception: receptionUC.NewService(db, catalogUC.NewService(db.ProductTypeRepo(), clock, catalogUC.DefaultCacheTTL), clock, receptionUC.DefaultReopenWindow),
+		webhooks:  webhooks,
+		relay: outboxUC.NewRelay(db.OutboxRepo(), webhooks, clock, outboxUC.Config{
+			PollInterval: time.Second,
+			Lease:        time.Minute,
+			BatchSize:    100,
+			MaxAttempts:  3,
+			BaseBackoff:  time.Millisecond,
+			MaxBackoff:   time.Millisecond,
+		}),
+		dispatcher: webhookUC.NewDispatcher(db, webhookad.NewHTTPSender(5*time.Second), clock, webhookUC.DispatcherConfig{
+			PollInterval:           time.Second,
+			Lease:                  time.Minute,
+			BatchSize:              100,
+			MaxAttempts:            3,
+			MaxConsecutiveFailures: maxConsecutiveFailures,
+			BaseBackoff:            time.Hour,
+			MaxBackoff:             time.Hour,
+		}),
+	}
+}
+
+// drain прогоняет outbox и очередь вебхуков до опустошения
+func (e *webhookEnv) drain(t *testing.T) {
+	t.Helper()
+
+	ctx := context.Background()
+	for {
+		n, err := e.relay.ProcessBatch(ctx)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+	for {
+		n, err := e.dispatcher.ProcessBatch(ctx)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+	}
+}
+
+func TestWebhookDeliveredOnReceptionClose(t *testing.T) {
+	env := setupWebhookEnv(t, 5)
+	ctx := context.Background()
+
+	receiver, received := newReceiver(t, http.StatusOK)
+	defer receiver.Close()
+
+	p, err := env.pvz.Create(ctx, pvzUC.CreateParams{City: "Москва"})
+	require.NoError(t, err)
+
+	sub, err := env.webhooks.Create(ctx, webhookUC.CreateParams{
+		URL:        receiver.URL,
+		EventTypes: []string{"reception_closed"},
+		PVZID:      p.ID,
+		Secret:     "s3cret",
+	})
+	require.NoError(t, err)
+
+	_, err = env.reception.Open(ctx, p.ID)
+	require.NoError(t, err)
+	_, err = env.reception.AddProduct(ctx, p.ID, "shoes")
+	require.NoError(t, err)
+	closed, err := env.reception.Close(ctx, p.ID)
+	require.NoError(t, err)
+
+	env.drain(t)
+
+	var hook receivedHook
+	select {
+	case hook = <-received:
+	default:
+		t.Fatal("webhook was not delivered")
+	}
+	require.Equal(t, webhookad.Sign("s3cret", hook.body), hook.signature)
+
+	var payload struct {
+		Type      string `json:"type"`
+		PVZID     string `json:"pvzId"`
+		Reception struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"reception"`
+	}
+	require.NoError(t, json.Unmarshal(hook.body, &payload))
+	require.Equal(t, "reception_closed", payload.Type)
+	require.Equal(t, p.ID, payload.PVZID)
+	require.Equal(t, closed.ID, payload.Reception.ID)
+	require.Equal(t, "closed", payload.Reception.Status)
+	require.Empty(t, received, "only reception_closed must be delivered")
+
+	deliveries, err := env.webhooks.Deliveries(ctx, sub.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, webhook.DeliveryDelivered, deliveries[0].Status)
+	require.Equal(t, http.StatusOK, deliveries[0].LastStatusCode)
+}
+
+func TestWebhookDisabledAfterRepeatedFailures(t *testing.T) {
+	env := setupWebhookEnv(t, 1)
+	ctx := context.Background()
+
+	receiver, received := newReceiver(t, http.StatusInternalServerError)
+	defer receiver.Close()
+
+	p, err := env.pvz.Create(ctx, pvzUC.CreateParams{City: "Казань"})
+	require.NoError(t, err)
+
+	sub, err := env.webhooks.Create(ctx, webhookUC.CreateParams{
+		URL:        receiver.URL,
+		EventTypes: []string{"reception_opened"},
+		City:       "Казань",
+		Secret:     "s3cret",
+	})
+	require.NoError(t, err)
+
+	_, err = env.reception.Open(ctx, p.ID)
+	require.NoError(t, err)
+
+	env.drain(t)
+	require.NotEmpty(t, received)
+
+	subs, err := env.webhooks.List(ctx)
+	require.NoError(t, err)
+	for _, s := range subs {
+		if s.ID == sub.ID {
+			require.False(t, s.Active)
+			require.NotNil(t, s.DisabledAt)
+		}
+	}
+
+	deliveries, err := env.webhooks.Deliveries(ctx, sub.ID, 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, deliveries)
+	require.Equal(t, http.StatusInternalServerError, deliveries[0].LastStatusCode)
+
+	require.NoError(t, env.webhooks.Delete(ctx, sub.ID))
+}
+
+func TestWebhookCityFilterResolvesAliases(t *testing.T) {
+	env := setupWebhookEnv(t, 5)
+	ctx := context.Background()
+
+	receiver, received := newReceiver(t, http.StatusOK)
+	defer receiver.Close()
+
+	sub, err := env.webhooks.Create(ctx, webhookUC.CreateParams{
+		URL:        receiver.URL,
+		EventTypes: []string{"reception_opened"},
+		City:       "saint petersburg",
+		Secret:     "s3cret",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, env.webhooks.Delete(ctx, sub.ID)) }()
+	require.Equal(t, "Санкт-Петербург", sub.City)
+
+	p, err := env.pvz.Create(ctx, pvzUC.CreateParams{City: "Saint Petersburg"})
+	require.NoError(t, err)
+	_, err = env.reception.Open(ctx, p.ID)
+	require.NoError(t, err)
+
+	env.drain(t)
+	// older pending events of other PVZs in the city may arrive as well
+	for {
+		select {
+		case hook := <-received:
+			if strings.Contains(string(hook.body), p.ID) {
+				return
+			}
+		default:
+			t.Fatal("webhook subscribed by alias was not delivered")
+		}
+	}
+}